@@ -0,0 +1,247 @@
+package api
+
+import (
+	"context"
+	"crypto/subtle"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"regexp"
+	"strings"
+	"time"
+
+	log "github.com/sirupsen/logrus"
+
+	"k8s.io/apimachinery/pkg/labels"
+
+	"github.com/linki/chaoskube/audit"
+	"github.com/linki/chaoskube/chaoskube"
+	"github.com/linki/chaoskube/metrics"
+	"github.com/linki/chaoskube/pause"
+	"github.com/linki/chaoskube/util"
+)
+
+// SourceAPI is the source name used for pauses requested via the HTTP API.
+const SourceAPI = "api"
+
+// Server serves a JSON API to control a running chaoskube instance.
+type Server struct {
+	chaoskube *chaoskube.Chaoskube
+	history   *audit.MemorySink
+	logger    log.FieldLogger
+	token     string
+}
+
+// Config is the effective configuration of a chaoskube instance.
+type Config struct {
+	Labels                   string       `json:"labels"`
+	Annotations              string       `json:"annotations"`
+	Kinds                    string       `json:"kinds"`
+	Namespaces               string       `json:"namespaces"`
+	NamespaceLabels          string       `json:"namespaceLabels"`
+	IncludedPodNames         string       `json:"includedPodNames"`
+	ExcludedPodNames         string       `json:"excludedPodNames"`
+	ExcludedWeekdays         []string     `json:"excludedWeekdays"`
+	ExcludedTimesOfDay       []string     `json:"excludedTimesOfDay"`
+	ExcludedDaysOfYear       []string     `json:"excludedDaysOfYear"`
+	Timezone                 string       `json:"timezone"`
+	MinimumAge               string       `json:"minimumAge"`
+	MaxKill                  int          `json:"maxKill"`
+	DryRun                   bool         `json:"dryRun"`
+	ArmedNamespaces          string       `json:"armedNamespaces"`
+	ArmedNamespaceLabels     string       `json:"armedNamespaceLabels"`
+	ArmedNamespaceAnnotation bool         `json:"armedNamespaceAnnotation"`
+	ClientNamespaceScope     string       `json:"clientNamespaceScope"`
+	Paused                   pause.Status `json:"paused"`
+}
+
+type pauseRequest struct {
+	// Duration optionally limits the pause, e.g. "30m"
+	Duration string `json:"duration"`
+	// Until optionally limits the pause to the given point in time
+	Until time.Time `json:"until"`
+}
+
+type dryRunRequest struct {
+	DryRun *bool `json:"dryRun"`
+}
+
+type dryRunResponse struct {
+	DryRun bool `json:"dryRun"`
+}
+
+type triggerResponse struct {
+	Error string `json:"error,omitempty"`
+}
+
+type errorResponse struct {
+	Error string `json:"error"`
+}
+
+// NewServer creates and returns a Server object for the given chaoskube instance which
+// serves the intervals recorded by history. All requests must present the given token
+// as a bearer token.
+func NewServer(chaoskube *chaoskube.Chaoskube, history *audit.MemorySink, logger log.FieldLogger, token string) *Server {
+	return &Server{
+		chaoskube: chaoskube,
+		history:   history,
+		logger:    logger.WithField("component", "api"),
+		token:     token,
+	}
+}
+
+// Handler returns an http.Handler serving the API endpoints below /api/.
+func (s *Server) Handler() http.Handler {
+	mux := http.NewServeMux()
+	mux.HandleFunc("POST /api/pause", s.handlePause)
+	mux.HandleFunc("POST /api/resume", s.handleResume)
+	mux.HandleFunc("POST /api/trigger", s.handleTrigger)
+	mux.HandleFunc("GET /api/dry-run", s.handleGetDryRun)
+	mux.HandleFunc("PUT /api/dry-run", s.handleSetDryRun)
+	mux.HandleFunc("GET /api/config", s.handleConfig)
+	mux.Handle("GET /api/history", HistoryHandler(s.history))
+	return s.Authenticate(mux)
+}
+
+// Authenticate rejects all requests to next that don't carry the configured bearer token.
+// It allows protecting handlers outside of /api/ with the same token.
+func (s *Server) Authenticate(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		token, found := strings.CutPrefix(r.Header.Get("Authorization"), "Bearer ")
+		if s.token == "" || !found || subtle.ConstantTimeCompare([]byte(token), []byte(s.token)) != 1 {
+			w.Header().Set("WWW-Authenticate", "Bearer")
+			writeJSON(w, http.StatusUnauthorized, errorResponse{Error: "unauthorized"})
+			return
+		}
+		next.ServeHTTP(w, r)
+	})
+}
+
+func (s *Server) handlePause(w http.ResponseWriter, r *http.Request) {
+	var req pauseRequest
+	if r.ContentLength != 0 {
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+			writeJSON(w, http.StatusBadRequest, errorResponse{Error: err.Error()})
+			return
+		}
+	}
+
+	until := req.Until
+	if req.Duration != "" {
+		duration, err := time.ParseDuration(req.Duration)
+		if err != nil {
+			writeJSON(w, http.StatusBadRequest, errorResponse{Error: err.Error()})
+			return
+		}
+		until = s.chaoskube.PauseSwitch.Now().Add(duration)
+	}
+
+	s.chaoskube.PauseSwitch.Pause(SourceAPI, until)
+	writeJSON(w, http.StatusOK, s.chaoskube.PauseSwitch.Status())
+}
+
+func (s *Server) handleResume(w http.ResponseWriter, _ *http.Request) {
+	s.chaoskube.PauseSwitch.Resume(SourceAPI)
+	writeJSON(w, http.StatusOK, s.chaoskube.PauseSwitch.Status())
+}
+
+func (s *Server) handleTrigger(w http.ResponseWriter, r *http.Request) {
+	s.logger.Info("triggering termination")
+
+	// don't abort the termination when the client goes away
+	err := s.chaoskube.Trigger(context.WithoutCancel(r.Context()))
+	if errors.Is(err, chaoskube.ErrRunInProgress) {
+		writeJSON(w, http.StatusConflict, triggerResponse{Error: err.Error()})
+		return
+	}
+	if err != nil {
+		s.logger.WithField("err", err).Error("failed to terminate victim")
+		metrics.ErrorsTotal.Inc()
+		writeJSON(w, http.StatusInternalServerError, triggerResponse{Error: err.Error()})
+		return
+	}
+
+	writeJSON(w, http.StatusOK, triggerResponse{})
+}
+
+func (s *Server) handleGetDryRun(w http.ResponseWriter, _ *http.Request) {
+	writeJSON(w, http.StatusOK, dryRunResponse{DryRun: s.chaoskube.IsDryRun()})
+}
+
+func (s *Server) handleSetDryRun(w http.ResponseWriter, r *http.Request) {
+	var req dryRunRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		writeJSON(w, http.StatusBadRequest, errorResponse{Error: err.Error()})
+		return
+	}
+	if req.DryRun == nil {
+		writeJSON(w, http.StatusBadRequest, errorResponse{Error: "missing field: dryRun"})
+		return
+	}
+
+	s.chaoskube.SetDryRun(*req.DryRun)
+	s.logger.WithField("dryRun", *req.DryRun).Info("changing dry-run mode")
+
+	writeJSON(w, http.StatusOK, dryRunResponse{DryRun: s.chaoskube.IsDryRun()})
+}
+
+func (s *Server) handleConfig(w http.ResponseWriter, _ *http.Request) {
+	writeJSON(w, http.StatusOK, EffectiveConfig(s.chaoskube))
+}
+
+// EffectiveConfig returns the configuration the given chaoskube instance currently runs with.
+func EffectiveConfig(c *chaoskube.Chaoskube) Config {
+	weekdays := make([]string, 0, len(c.ExcludedWeekdays))
+	for _, wd := range c.ExcludedWeekdays {
+		weekdays = append(weekdays, wd.String())
+	}
+
+	timesOfDay := make([]string, 0, len(c.ExcludedTimesOfDay))
+	for _, tp := range c.ExcludedTimesOfDay {
+		timesOfDay = append(timesOfDay, tp.String())
+	}
+
+	return Config{
+		Labels:                   c.Labels.String(),
+		Annotations:              c.Annotations.String(),
+		Kinds:                    c.Kinds.String(),
+		Namespaces:               c.Namespaces.String(),
+		NamespaceLabels:          c.NamespaceLabels.String(),
+		IncludedPodNames:         regexpString(c.IncludedPodNames),
+		ExcludedPodNames:         regexpString(c.ExcludedPodNames),
+		ExcludedWeekdays:         weekdays,
+		ExcludedTimesOfDay:       timesOfDay,
+		ExcludedDaysOfYear:       util.FormatDays(c.ExcludedDaysOfYear),
+		Timezone:                 c.Timezone.String(),
+		MinimumAge:               c.MinimumAge.String(),
+		MaxKill:                  c.MaxKill,
+		DryRun:                   c.IsDryRun(),
+		ArmedNamespaces:          selectorString(c.ArmedNamespaces),
+		ArmedNamespaceLabels:     selectorString(c.ArmedNamespaceLabels),
+		ArmedNamespaceAnnotation: c.ArmedNamespaceAnnotation,
+		ClientNamespaceScope:     c.ClientNamespaceScope,
+		Paused:                   c.PauseSwitch.Status(),
+	}
+}
+
+func selectorString(s labels.Selector) string {
+	if s == nil {
+		return ""
+	}
+	return s.String()
+}
+
+func regexpString(r *regexp.Regexp) string {
+	if r == nil {
+		return ""
+	}
+	return r.String()
+}
+
+func writeJSON(w http.ResponseWriter, status int, v interface{}) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	if err := json.NewEncoder(w).Encode(v); err != nil {
+		log.WithField("err", err).Error("failed to write response")
+	}
+}
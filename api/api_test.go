@@ -0,0 +1,224 @@
+package api
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	log "github.com/sirupsen/logrus"
+	"github.com/sirupsen/logrus/hooks/test"
+
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/client-go/kubernetes/fake"
+
+	"github.com/linki/chaoskube/audit"
+	"github.com/linki/chaoskube/chaoskube"
+	"github.com/linki/chaoskube/internal/testutil"
+	"github.com/linki/chaoskube/terminator"
+	"github.com/linki/chaoskube/util"
+
+	"github.com/stretchr/testify/suite"
+)
+
+type ServerSuite struct {
+	testutil.TestSuite
+}
+
+var (
+	logger, logOutput = test.NewNullLogger()
+)
+
+const token = "s3cr3t"
+
+func (suite *ServerSuite) SetupTest() {
+	logger.SetLevel(log.DebugLevel)
+	logOutput.Reset()
+}
+
+func (suite *ServerSuite) TestAuthentication() {
+	handler := NewServer(newChaoskube(), audit.NewMemorySink(10), logger, token).Handler()
+
+	for _, tt := range []struct {
+		header string
+		status int
+	}{
+		{"", http.StatusUnauthorized},
+		{"Bearer", http.StatusUnauthorized},
+		{"Bearer wrong", http.StatusUnauthorized},
+		{"Basic " + token, http.StatusUnauthorized},
+		{"Bearer " + token, http.StatusOK},
+	} {
+		req := httptest.NewRequest(http.MethodGet, "/api/config", nil)
+		if tt.header != "" {
+			req.Header.Set("Authorization", tt.header)
+		}
+		res := httptest.NewRecorder()
+
+		handler.ServeHTTP(res, req)
+		suite.Equal(tt.status, res.Code, tt.header)
+	}
+}
+
+func (suite *ServerSuite) TestEmptyTokenRejectsEverything() {
+	handler := NewServer(newChaoskube(), audit.NewMemorySink(10), logger, "").Handler()
+
+	req := httptest.NewRequest(http.MethodGet, "/api/config", nil)
+	req.Header.Set("Authorization", "Bearer ")
+	res := httptest.NewRecorder()
+
+	handler.ServeHTTP(res, req)
+	suite.Equal(http.StatusUnauthorized, res.Code)
+}
+
+func (suite *ServerSuite) TestHistory() {
+	history := audit.NewMemorySink(10)
+	for _, record := range []audit.Record{
+		{Time: now, Outcome: audit.OutcomeTerminated},
+		{Time: now.Add(10 * time.Minute), Outcome: audit.OutcomeSkipped, SkipReason: "weekday"},
+	} {
+		suite.Require().NoError(history.Write(context.Background(), record))
+	}
+	handler := NewServer(newChaoskube(), history, logger, token).Handler()
+
+	get := func(target string, authorized bool) *httptest.ResponseRecorder {
+		req := httptest.NewRequest(http.MethodGet, target, nil)
+		if authorized {
+			req.Header.Set("Authorization", "Bearer "+token)
+		}
+		res := httptest.NewRecorder()
+		handler.ServeHTTP(res, req)
+		return res
+	}
+
+	suite.Equal(http.StatusUnauthorized, get("/api/history", false).Code)
+
+	res := get("/api/history", true)
+	suite.Equal(http.StatusOK, res.Code)
+	var records []audit.Record
+	suite.Require().NoError(json.Unmarshal(res.Body.Bytes(), &records))
+	suite.Len(records, 2)
+
+	res = get("/api/history?limit=1", true)
+	suite.Equal(http.StatusOK, res.Code)
+	suite.Require().NoError(json.Unmarshal(res.Body.Bytes(), &records))
+	suite.Len(records, 1)
+	suite.Equal("weekday", records[0].SkipReason)
+
+	suite.Equal(http.StatusBadRequest, get("/api/history?limit=-1", true).Code)
+}
+
+func (suite *ServerSuite) TestPauseAndResume() {
+	chaoskube := newChaoskube()
+	handler := NewServer(chaoskube, audit.NewMemorySink(10), logger, token).Handler()
+
+	res := suite.request(handler, http.MethodPost, "/api/pause", "")
+	suite.Equal(http.StatusOK, res.Code)
+	suite.True(chaoskube.PauseSwitch.Paused())
+	suite.JSONEq(`{"paused":true,"sources":["api"]}`, res.Body.String())
+
+	res = suite.request(handler, http.MethodPost, "/api/resume", "")
+	suite.Equal(http.StatusOK, res.Code)
+	suite.False(chaoskube.PauseSwitch.Paused())
+
+	res = suite.request(handler, http.MethodPost, "/api/pause", `{"duration":"1h"}`)
+	suite.Equal(http.StatusOK, res.Code)
+
+	status := chaoskube.PauseSwitch.Status()
+	suite.True(status.Paused)
+	suite.WithinDuration(time.Now().Add(time.Hour), status.Until, time.Minute)
+
+	res = suite.request(handler, http.MethodPost, "/api/pause", `{"duration":"forever"}`)
+	suite.Equal(http.StatusBadRequest, res.Code)
+}
+
+func (suite *ServerSuite) TestTrigger() {
+	chaoskube := newChaoskube()
+	chaoskube.Now = func() time.Time { return time.Date(1869, 9, 24, 15, 4, 5, 0, time.UTC) }
+	handler := NewServer(chaoskube, audit.NewMemorySink(10), logger, token).Handler()
+
+	pod := util.NewPod("default", "foo", v1.PodRunning)
+	_, err := chaoskube.Client.CoreV1().Pods(pod.Namespace).Create(context.Background(), &pod, metav1.CreateOptions{})
+	suite.Require().NoError(err)
+
+	res := suite.request(handler, http.MethodPost, "/api/trigger", "")
+	suite.Equal(http.StatusOK, res.Code)
+	suite.JSONEq(`{}`, res.Body.String())
+
+	pods, err := chaoskube.Client.CoreV1().Pods(v1.NamespaceAll).List(context.Background(), metav1.ListOptions{})
+	suite.Require().NoError(err)
+	suite.Empty(pods.Items)
+}
+
+func (suite *ServerSuite) TestDryRun() {
+	chaoskube := newChaoskube()
+	handler := NewServer(chaoskube, audit.NewMemorySink(10), logger, token).Handler()
+
+	res := suite.request(handler, http.MethodGet, "/api/dry-run", "")
+	suite.Equal(http.StatusOK, res.Code)
+	suite.JSONEq(`{"dryRun":false}`, res.Body.String())
+
+	res = suite.request(handler, http.MethodPut, "/api/dry-run", `{"dryRun":true}`)
+	suite.Equal(http.StatusOK, res.Code)
+	suite.JSONEq(`{"dryRun":true}`, res.Body.String())
+	suite.True(chaoskube.IsDryRun())
+	suite.AssertLog(logOutput, log.InfoLevel, "changing dry-run mode", log.Fields{"dryRun": true})
+
+	res = suite.request(handler, http.MethodPut, "/api/dry-run", `{}`)
+	suite.Equal(http.StatusBadRequest, res.Code)
+	suite.True(chaoskube.IsDryRun())
+}
+
+func (suite *ServerSuite) TestConfig() {
+	handler := NewServer(newChaoskube(), audit.NewMemorySink(10), logger, token).Handler()
+
+	res := suite.request(handler, http.MethodGet, "/api/config", "")
+	suite.Equal(http.StatusOK, res.Code)
+
+	var config Config
+	suite.Require().NoError(json.NewDecoder(res.Body).Decode(&config))
+
+	suite.Equal("app=foo", config.Labels)
+	suite.Equal([]string{"Saturday"}, config.ExcludedWeekdays)
+	suite.Equal("UTC", config.Timezone)
+	suite.Equal(1, config.MaxKill)
+	suite.False(config.DryRun)
+	suite.Empty(config.ArmedNamespaces)
+	suite.False(config.ArmedNamespaceAnnotation)
+	suite.False(config.Paused.Paused)
+}
+
+func TestServerSuite(t *testing.T) {
+	suite.Run(t, new(ServerSuite))
+}
+
+func (suite *ServerSuite) request(handler http.Handler, method, path, body string) *httptest.ResponseRecorder {
+	req := httptest.NewRequest(method, path, strings.NewReader(body))
+	req.Header.Set("Authorization", "Bearer "+token)
+	res := httptest.NewRecorder()
+
+	handler.ServeHTTP(res, req)
+	return res
+}
+
+// newChaoskube returns a chaoskube instance targeting pods labeled app=foo.
+func newChaoskube() *chaoskube.Chaoskube {
+	client := fake.NewSimpleClientset()
+
+	chaoskube, err := chaoskube.NewWithOptions(client,
+		chaoskube.WithLabels(labels.SelectorFromSet(labels.Set{"app": "foo"})),
+		chaoskube.WithQuietTimes([]time.Weekday{time.Saturday}, nil, nil),
+		chaoskube.WithLogger(logger),
+		chaoskube.WithTerminator(terminator.NewDeletePodTerminator(client, logger, 10*time.Second)),
+	)
+	if err != nil {
+		panic(err)
+	}
+
+	return chaoskube
+}
@@ -0,0 +1,124 @@
+package api
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"sort"
+	"text/tabwriter"
+	"time"
+
+	log "github.com/sirupsen/logrus"
+
+	v1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/util/duration"
+
+	"github.com/linki/chaoskube/chaoskube"
+	"github.com/linki/chaoskube/util"
+)
+
+// The output formats of candidates and explanations.
+const (
+	// OutputTable renders a human-readable table.
+	OutputTable = "table"
+	// OutputJSON renders a JSON array.
+	OutputJSON = "json"
+)
+
+// Pod summarizes a pod for display purposes.
+type Pod struct {
+	Namespace         string    `json:"namespace"`
+	Name              string    `json:"name"`
+	OwnerKind         string    `json:"ownerKind,omitempty"`
+	OwnerName         string    `json:"ownerName,omitempty"`
+	Age               string    `json:"age"`
+	CreationTimestamp time.Time `json:"creationTimestamp"`
+}
+
+// Summarize turns the given pods into summaries sorted by namespace and name.
+func Summarize(pods []v1.Pod, now time.Time) []Pod {
+	summaries := make([]Pod, 0, len(pods))
+
+	for _, pod := range pods {
+		summary := Pod{
+			Namespace:         pod.Namespace,
+			Name:              pod.Name,
+			Age:               duration.HumanDuration(now.Sub(pod.CreationTimestamp.Time)),
+			CreationTimestamp: pod.CreationTimestamp.Time,
+		}
+		if owner := util.OwnerOf(pod); owner != nil {
+			summary.OwnerKind = owner.Kind
+			summary.OwnerName = owner.Name
+		}
+		summaries = append(summaries, summary)
+	}
+
+	sort.Slice(summaries, func(i, j int) bool {
+		if summaries[i].Namespace != summaries[j].Namespace {
+			return summaries[i].Namespace < summaries[j].Namespace
+		}
+		return summaries[i].Name < summaries[j].Name
+	})
+
+	return summaries
+}
+
+// WritePods writes the given pods to w either as a table or as JSON.
+func WritePods(w io.Writer, pods []Pod, output string) error {
+	switch output {
+	case OutputJSON:
+		return json.NewEncoder(w).Encode(pods)
+	case OutputTable:
+		tw := tabwriter.NewWriter(w, 0, 0, 3, ' ', 0)
+		fmt.Fprintln(tw, "NAMESPACE\tNAME\tOWNER\tAGE")
+		for _, pod := range pods {
+			owner := "<none>"
+			if pod.OwnerKind != "" {
+				owner = pod.OwnerKind + "/" + pod.OwnerName
+			}
+			fmt.Fprintf(tw, "%s\t%s\t%s\t%s\n", pod.Namespace, pod.Name, owner, pod.Age)
+		}
+		return tw.Flush()
+	default:
+		return fmt.Errorf("unsupported output format: %s", output)
+	}
+}
+
+// CandidatesHandler returns an http.Handler that lists the current candidates of the given
+// chaoskube instance without terminating any of them. It responds with JSON by default and
+// with a table when the query parameter output is set to table.
+func CandidatesHandler(c *chaoskube.Chaoskube) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		output := r.URL.Query().Get("output")
+		if output == "" {
+			output = OutputJSON
+		}
+		if output != OutputJSON && output != OutputTable {
+			writeJSON(w, http.StatusBadRequest, errorResponse{Error: "unsupported output format: " + output})
+			return
+		}
+
+		pods, err := c.Candidates(r.Context())
+		if err != nil {
+			writeJSON(w, http.StatusInternalServerError, errorResponse{Error: err.Error()})
+			return
+		}
+
+		var body bytes.Buffer
+		if err := WritePods(&body, Summarize(pods, c.Now()), output); err != nil {
+			writeJSON(w, http.StatusInternalServerError, errorResponse{Error: err.Error()})
+			return
+		}
+
+		if output == OutputJSON {
+			w.Header().Set("Content-Type", "application/json")
+		} else {
+			w.Header().Set("Content-Type", "text/plain; charset=utf-8")
+		}
+		if _, err := body.WriteTo(w); err != nil {
+			log.WithField("err", err).Error("failed to write response")
+		}
+	})
+}
@@ -0,0 +1,97 @@
+package api
+
+import (
+	"bytes"
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/linki/chaoskube/internal/testutil"
+	"github.com/linki/chaoskube/util"
+
+	"github.com/stretchr/testify/suite"
+)
+
+type CandidatesSuite struct {
+	testutil.TestSuite
+}
+
+var now = time.Date(1869, 9, 24, 15, 4, 5, 0, time.UTC)
+
+func (suite *CandidatesSuite) TestSummarize() {
+	foo := util.NewPodWithOwner("testing", "foo", v1.PodRunning, "parent")
+	foo.OwnerReferences[0].Name = "foo-5d4b7"
+	foo.CreationTimestamp = metav1.NewTime(now.Add(-3 * time.Hour))
+
+	bar := util.NewPod("default", "bar", v1.PodRunning)
+	bar.CreationTimestamp = metav1.NewTime(now.Add(-90 * time.Second))
+
+	suite.Equal([]Pod{
+		{Namespace: "default", Name: "bar", Age: "90s", CreationTimestamp: bar.CreationTimestamp.Time},
+		{Namespace: "testing", Name: "foo", OwnerKind: "testkind", OwnerName: "foo-5d4b7", Age: "3h", CreationTimestamp: foo.CreationTimestamp.Time},
+	}, Summarize([]v1.Pod{foo, bar}, now))
+}
+
+func (suite *CandidatesSuite) TestWritePods() {
+	pods := []Pod{
+		{Namespace: "default", Name: "bar", Age: "90s", CreationTimestamp: now},
+		{Namespace: "testing", Name: "foo", OwnerKind: "ReplicaSet", OwnerName: "foo-5d4b7", Age: "3h", CreationTimestamp: now},
+	}
+
+	var table bytes.Buffer
+	suite.Require().NoError(WritePods(&table, pods, OutputTable))
+	suite.Equal(""+
+		"NAMESPACE   NAME   OWNER                  AGE\n"+
+		"default     bar    <none>                 90s\n"+
+		"testing     foo    ReplicaSet/foo-5d4b7   3h\n", table.String())
+
+	var json bytes.Buffer
+	suite.Require().NoError(WritePods(&json, pods[:1], OutputJSON))
+	suite.JSONEq(`[{"namespace":"default","name":"bar","age":"90s","creationTimestamp":"1869-09-24T15:04:05Z"}]`, json.String())
+
+	suite.EqualError(WritePods(&json, pods, "yaml"), "unsupported output format: yaml")
+}
+
+func (suite *CandidatesSuite) TestCandidatesHandler() {
+	chaoskube := newChaoskube()
+	chaoskube.Now = func() time.Time { return now }
+
+	for _, pod := range []v1.Pod{
+		util.NewPod("default", "foo", v1.PodRunning),
+		util.NewPod("default", "bar", v1.PodRunning),
+	} {
+		pod.CreationTimestamp = metav1.NewTime(now.Add(-time.Hour))
+		_, err := chaoskube.Client.CoreV1().Pods(pod.Namespace).Create(context.Background(), &pod, metav1.CreateOptions{})
+		suite.Require().NoError(err)
+	}
+
+	handler := CandidatesHandler(chaoskube)
+
+	res := httptest.NewRecorder()
+	handler.ServeHTTP(res, httptest.NewRequest(http.MethodGet, "/candidates", nil))
+	suite.Equal(http.StatusOK, res.Code)
+	suite.Equal("application/json", res.Header().Get("Content-Type"))
+	suite.JSONEq(`[{"namespace":"default","name":"foo","age":"60m","creationTimestamp":"1869-09-24T14:04:05Z"}]`, res.Body.String())
+
+	res = httptest.NewRecorder()
+	handler.ServeHTTP(res, httptest.NewRequest(http.MethodGet, "/candidates?output=table", nil))
+	suite.Equal(http.StatusOK, res.Code)
+	suite.Contains(res.Body.String(), "default     foo    <none>   60m")
+
+	res = httptest.NewRecorder()
+	handler.ServeHTTP(res, httptest.NewRequest(http.MethodGet, "/candidates?output=yaml", nil))
+	suite.Equal(http.StatusBadRequest, res.Code)
+
+	pods, err := chaoskube.Client.CoreV1().Pods(v1.NamespaceAll).List(context.Background(), metav1.ListOptions{})
+	suite.Require().NoError(err)
+	suite.Len(pods.Items, 2)
+}
+
+func TestCandidatesSuite(t *testing.T) {
+	suite.Run(t, new(CandidatesSuite))
+}
@@ -0,0 +1,176 @@
+package api
+
+import (
+	"bytes"
+	"html/template"
+	"net/http"
+	"time"
+
+	log "github.com/sirupsen/logrus"
+
+	"github.com/linki/chaoskube/audit"
+	"github.com/linki/chaoskube/chaoskube"
+	"github.com/linki/chaoskube/pause"
+)
+
+// Status summarizes the state of a running chaoskube instance.
+type Status struct {
+	// the paused state
+	Paused pause.Status `json:"paused"`
+	// why terminations are currently suspended by the quiet times, empty if they aren't
+	QuietTime string `json:"quietTime,omitempty"`
+	// whether dry-run mode is enabled
+	DryRun bool `json:"dryRun"`
+	// when the next interval is expected to start, zero if unknown
+	NextRun time.Time `json:"nextRun,omitzero"`
+	// the effective configuration, nil if it's hidden
+	Config *Config `json:"config,omitempty"`
+	// the most recent intervals, most recent first, nil if they're hidden
+	History []audit.Record `json:"history,omitempty"`
+}
+
+// Dashboard serves an HTML status page with the history of recent intervals.
+type Dashboard struct {
+	// HideConfig leaves the effective configuration and the history off the status page,
+	// e.g. because they're only meant to be seen by clients of the authenticated API.
+	HideConfig bool
+
+	chaoskube *chaoskube.Chaoskube
+	history   *audit.MemorySink
+	nextRun   func() time.Time
+}
+
+// NewDashboard creates and returns a Dashboard for the given chaoskube instance. It shows
+// the intervals recorded by history and asks nextRun when the next interval starts, which
+// may be nil if that's unknown.
+func NewDashboard(chaoskube *chaoskube.Chaoskube, history *audit.MemorySink, nextRun func() time.Time) *Dashboard {
+	return &Dashboard{
+		chaoskube: chaoskube,
+		history:   history,
+		nextRun:   nextRun,
+	}
+}
+
+// Status returns the current status of the chaoskube instance.
+func (d *Dashboard) Status() Status {
+	status := Status{
+		Paused:    d.chaoskube.PauseSwitch.Status(),
+		QuietTime: d.chaoskube.QuietTime(),
+		DryRun:    d.chaoskube.IsDryRun(),
+	}
+
+	if !d.HideConfig {
+		config := EffectiveConfig(d.chaoskube)
+		status.Config = &config
+		status.History = d.history.Records()
+	}
+
+	if d.nextRun != nil {
+		status.NextRun = d.nextRun()
+	}
+
+	return status
+}
+
+// Handler returns an http.Handler serving the HTML status page.
+func (d *Dashboard) Handler() http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		var body bytes.Buffer
+		page := dashboardData{Status: d.Status(), HideHistory: d.HideConfig}
+		if err := dashboardPage.Execute(&body, page); err != nil {
+			log.WithField("err", err).Error("failed to render dashboard")
+			http.Error(w, "failed to render dashboard", http.StatusInternalServerError)
+			return
+		}
+
+		w.Header().Set("Content-Type", "text/html; charset=utf-8")
+		if _, err := body.WriteTo(w); err != nil {
+			log.WithField("err", err).Error("failed to write response")
+		}
+	})
+}
+
+// dashboardData is what the status page is rendered from.
+type dashboardData struct {
+	Status
+	// whether the history is left off the page
+	HideHistory bool
+}
+
+var dashboardPage = template.Must(template.New("dashboard").Funcs(template.FuncMap{
+	"timestamp": func(t time.Time) string { return t.Format(time.RFC3339) },
+}).Parse(`<html>
+	<head>
+		<title>chaoskube</title>
+	</head>
+	<body>
+		<h1>chaoskube</h1>
+		{{- with .Paused }}
+		{{- if .Paused }}
+		<p>Paused by {{ range $i, $s := .Sources }}{{ if $i }}, {{ end }}{{ $s }}{{ end }}{{ if not .Until.IsZero }} until {{ timestamp .Until }}{{ end }}</p>
+		{{- else }}
+		<p>Running</p>
+		{{- end }}
+		{{- end }}
+		{{- if .QuietTime }}
+		<p>Quiet time ({{ .QuietTime }})</p>
+		{{- end }}
+		{{- if .DryRun }}
+		<p>Dry-run mode</p>
+		{{- end }}
+		{{- if not .NextRun.IsZero }}
+		<p>Next run at {{ timestamp .NextRun }}</p>
+		{{- end }}
+
+		<h2>Recent intervals</h2>
+		{{- if .HideHistory }}
+		<p>The history requires the API token on <code>/api/history</code>.</p>
+		{{- else }}
+		<table>
+			<tr><th>Time</th><th>Outcome</th><th>Candidates</th><th>Victims</th><th>Details</th></tr>
+			{{- range .History }}
+			<tr>
+				<td>{{ timestamp .Time }}</td>
+				<td>{{ .Outcome }}{{ if .DryRun }} (dry-run){{ end }}</td>
+				<td>{{ .Candidates }}</td>
+				<td>{{ range $i, $v := .Victims }}{{ if $i }}, {{ end }}{{ $v.Namespace }}/{{ $v.Name }}{{ end }}</td>
+				<td>{{ .SkipReason }}{{ range .Errors }} {{ . }}{{ end }}</td>
+			</tr>
+			{{- else }}
+			<tr><td colspan="5">No intervals yet</td></tr>
+			{{- end }}
+		</table>
+		<p><a href="/api/history">History as JSON</a></p>
+		{{- end }}
+
+		{{- with .Config }}
+
+		<h2>Configuration</h2>
+		<table>
+			<tr><td>Labels</td><td>{{ .Labels }}</td></tr>
+			<tr><td>Annotations</td><td>{{ .Annotations }}</td></tr>
+			<tr><td>Kinds</td><td>{{ .Kinds }}</td></tr>
+			<tr><td>Namespaces</td><td>{{ .Namespaces }}</td></tr>
+			<tr><td>Namespace labels</td><td>{{ .NamespaceLabels }}</td></tr>
+			<tr><td>Included pod names</td><td>{{ .IncludedPodNames }}</td></tr>
+			<tr><td>Excluded pod names</td><td>{{ .ExcludedPodNames }}</td></tr>
+			<tr><td>Excluded weekdays</td><td>{{ range $i, $s := .ExcludedWeekdays }}{{ if $i }}, {{ end }}{{ $s }}{{ end }}</td></tr>
+			<tr><td>Excluded times of day</td><td>{{ range $i, $s := .ExcludedTimesOfDay }}{{ if $i }}, {{ end }}{{ $s }}{{ end }}</td></tr>
+			<tr><td>Excluded days of year</td><td>{{ range $i, $s := .ExcludedDaysOfYear }}{{ if $i }}, {{ end }}{{ $s }}{{ end }}</td></tr>
+			<tr><td>Timezone</td><td>{{ .Timezone }}</td></tr>
+			<tr><td>Minimum age</td><td>{{ .MinimumAge }}</td></tr>
+			<tr><td>Max kill</td><td>{{ .MaxKill }}</td></tr>
+			<tr><td>Armed namespaces</td><td>{{ .ArmedNamespaces }}</td></tr>
+			<tr><td>Armed namespace labels</td><td>{{ .ArmedNamespaceLabels }}</td></tr>
+			<tr><td>Armed namespace annotation</td><td>{{ .ArmedNamespaceAnnotation }}</td></tr>
+			<tr><td>Client namespace scope</td><td>{{ .ClientNamespaceScope }}</td></tr>
+		</table>
+		{{- end }}
+
+		<p><a href="/metrics">Metrics</a></p>
+		<p><a href="/candidates?output=table">Candidates</a></p>
+		<p><a href="/debug/explain?output=table">Explain</a></p>
+		<p><a href="/healthz">Health Check</a></p>
+		<p><a href="/debug/pprof">pprof</a></p>
+	</body>
+</html>`))
@@ -0,0 +1,78 @@
+package api
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/linki/chaoskube/audit"
+	"github.com/linki/chaoskube/internal/testutil"
+
+	"github.com/stretchr/testify/suite"
+)
+
+type DashboardSuite struct {
+	testutil.TestSuite
+}
+
+func (suite *DashboardSuite) newDashboard() *Dashboard {
+	chaoskube := newChaoskube()
+	// a Saturday, which newChaoskube excludes
+	chaoskube.Now = func() time.Time { return time.Date(1869, 9, 25, 15, 4, 5, 0, time.UTC) }
+
+	history := audit.NewMemorySink(10)
+	for _, record := range []audit.Record{
+		{Time: now, Outcome: audit.OutcomeTerminated, Candidates: 2, Victims: []audit.Victim{{Namespace: "default", Name: "foo"}}},
+		{Time: now.Add(10 * time.Minute), Outcome: audit.OutcomeSkipped, SkipReason: "weekday"},
+	} {
+		suite.Require().NoError(history.Write(context.Background(), record))
+	}
+
+	return NewDashboard(chaoskube, history, func() time.Time { return now.Add(20 * time.Minute) })
+}
+
+func (suite *DashboardSuite) TestStatus() {
+	dashboard := suite.newDashboard()
+
+	status := dashboard.Status()
+	suite.False(status.Paused.Paused)
+	suite.Equal("weekday", status.QuietTime)
+	suite.Equal(now.Add(20*time.Minute), status.NextRun)
+	suite.Require().NotNil(status.Config)
+	suite.Equal("app=foo", status.Config.Labels)
+	suite.Len(status.History, 2)
+	suite.Equal(audit.OutcomeSkipped, status.History[0].Outcome)
+
+	dashboard.HideConfig = true
+	suite.Nil(dashboard.Status().Config)
+	suite.Nil(dashboard.Status().History)
+}
+
+func (suite *DashboardSuite) TestHandler() {
+	dashboard := suite.newDashboard()
+
+	res := httptest.NewRecorder()
+	dashboard.Handler().ServeHTTP(res, httptest.NewRequest(http.MethodGet, "/", nil))
+	suite.Equal(http.StatusOK, res.Code)
+
+	body := res.Body.String()
+	suite.Contains(body, "<p>Running</p>")
+	suite.Contains(body, "<p>Quiet time (weekday)</p>")
+	suite.Contains(body, "<p>Next run at 1869-09-24T15:24:05Z</p>")
+	suite.Contains(body, "<td>default/foo</td>")
+	suite.Contains(body, "<td>app=foo</td>")
+
+	dashboard.HideConfig = true
+	res = httptest.NewRecorder()
+	dashboard.Handler().ServeHTTP(res, httptest.NewRequest(http.MethodGet, "/", nil))
+	body = res.Body.String()
+	suite.NotContains(body, "Configuration")
+	suite.NotContains(body, "default/foo")
+	suite.Contains(body, "The history requires the API token")
+}
+
+func TestDashboardSuite(t *testing.T) {
+	suite.Run(t, new(DashboardSuite))
+}
@@ -0,0 +1,101 @@
+package api
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"text/tabwriter"
+
+	log "github.com/sirupsen/logrus"
+
+	"github.com/linki/chaoskube/chaoskube"
+)
+
+// SelectExplanation returns the explanation of the pod given in the form namespace/name.
+// An empty pod selects all explanations.
+func SelectExplanation(explanations []chaoskube.Explanation, pod string) ([]chaoskube.Explanation, error) {
+	if pod == "" {
+		return explanations, nil
+	}
+
+	namespace, name, found := strings.Cut(pod, "/")
+	if !found {
+		return nil, fmt.Errorf("invalid pod %q, expected namespace/name", pod)
+	}
+
+	for _, explanation := range explanations {
+		if explanation.Namespace == namespace && explanation.Name == name {
+			return []chaoskube.Explanation{explanation}, nil
+		}
+	}
+
+	return nil, fmt.Errorf("pod %s not found", pod)
+}
+
+// WriteExplanations writes the given explanations to w either as a table or as JSON.
+func WriteExplanations(w io.Writer, explanations []chaoskube.Explanation, output string) error {
+	switch output {
+	case OutputJSON:
+		return json.NewEncoder(w).Encode(explanations)
+	case OutputTable:
+		tw := tabwriter.NewWriter(w, 0, 0, 3, ' ', 0)
+		fmt.Fprintln(tw, "NAMESPACE\tNAME\tCANDIDATE\tFILTER\tREASON")
+		for _, e := range explanations {
+			filter, reason := e.Filter, e.Reason
+			if e.Candidate {
+				filter, reason = "-", "-"
+			}
+			fmt.Fprintf(tw, "%s\t%s\t%t\t%s\t%s\n", e.Namespace, e.Name, e.Candidate, filter, reason)
+		}
+		return tw.Flush()
+	default:
+		return fmt.Errorf("unsupported output format: %s", output)
+	}
+}
+
+// ExplainHandler returns an http.Handler that reports for each pod why it is or isn't a candidate
+// of the given chaoskube instance. The query parameter pod restricts the result to a single pod in
+// the form namespace/name. It responds with JSON by default and with a table when the query
+// parameter output is set to table.
+func ExplainHandler(c *chaoskube.Chaoskube) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		output := r.URL.Query().Get("output")
+		if output == "" {
+			output = OutputJSON
+		}
+		if output != OutputJSON && output != OutputTable {
+			writeJSON(w, http.StatusBadRequest, errorResponse{Error: "unsupported output format: " + output})
+			return
+		}
+
+		explanations, err := c.Explain(r.Context())
+		if err != nil {
+			writeJSON(w, http.StatusInternalServerError, errorResponse{Error: err.Error()})
+			return
+		}
+
+		explanations, err = SelectExplanation(explanations, r.URL.Query().Get("pod"))
+		if err != nil {
+			writeJSON(w, http.StatusNotFound, errorResponse{Error: err.Error()})
+			return
+		}
+
+		var body bytes.Buffer
+		if err := WriteExplanations(&body, explanations, output); err != nil {
+			writeJSON(w, http.StatusInternalServerError, errorResponse{Error: err.Error()})
+			return
+		}
+
+		if output == OutputJSON {
+			w.Header().Set("Content-Type", "application/json")
+		} else {
+			w.Header().Set("Content-Type", "text/plain; charset=utf-8")
+		}
+		if _, err := body.WriteTo(w); err != nil {
+			log.WithField("err", err).Error("failed to write response")
+		}
+	})
+}
@@ -0,0 +1,99 @@
+package api
+
+import (
+	"bytes"
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/linki/chaoskube/chaoskube"
+	"github.com/linki/chaoskube/internal/testutil"
+	"github.com/linki/chaoskube/util"
+
+	"github.com/stretchr/testify/suite"
+)
+
+type ExplainSuite struct {
+	testutil.TestSuite
+}
+
+var explanations = []chaoskube.Explanation{
+	{Namespace: "default", Name: "foo", Candidate: true},
+	{Namespace: "testing", Name: "bar", Filter: "phase", Reason: "pod isn't in phase Running"},
+}
+
+func (suite *ExplainSuite) TestSelectExplanation() {
+	selected, err := SelectExplanation(explanations, "")
+	suite.Require().NoError(err)
+	suite.Equal(explanations, selected)
+
+	selected, err = SelectExplanation(explanations, "testing/bar")
+	suite.Require().NoError(err)
+	suite.Equal(explanations[1:], selected)
+
+	_, err = SelectExplanation(explanations, "testing/foo")
+	suite.EqualError(err, "pod testing/foo not found")
+
+	_, err = SelectExplanation(explanations, "foo")
+	suite.EqualError(err, `invalid pod "foo", expected namespace/name`)
+}
+
+func (suite *ExplainSuite) TestWriteExplanations() {
+	var table bytes.Buffer
+	suite.Require().NoError(WriteExplanations(&table, explanations, OutputTable))
+	suite.Equal(""+
+		"NAMESPACE   NAME   CANDIDATE   FILTER   REASON\n"+
+		"default     foo    true        -        -\n"+
+		"testing     bar    false       phase    pod isn't in phase Running\n", table.String())
+
+	var json bytes.Buffer
+	suite.Require().NoError(WriteExplanations(&json, explanations[:1], OutputJSON))
+	suite.JSONEq(`[{"namespace":"default","name":"foo","candidate":true}]`, json.String())
+
+	suite.EqualError(WriteExplanations(&json, explanations, "yaml"), "unsupported output format: yaml")
+}
+
+func (suite *ExplainSuite) TestExplainHandler() {
+	chaoskube := newChaoskube()
+
+	for _, pod := range []v1.Pod{
+		util.NewPod("default", "foo", v1.PodRunning),
+		util.NewPod("testing", "bar", v1.PodRunning),
+	} {
+		_, err := chaoskube.Client.CoreV1().Pods(pod.Namespace).Create(context.Background(), &pod, metav1.CreateOptions{})
+		suite.Require().NoError(err)
+	}
+
+	handler := ExplainHandler(chaoskube)
+
+	res := httptest.NewRecorder()
+	handler.ServeHTTP(res, httptest.NewRequest(http.MethodGet, "/debug/explain", nil))
+	suite.Equal(http.StatusOK, res.Code)
+	suite.Equal("application/json", res.Header().Get("Content-Type"))
+	suite.JSONEq(`[
+		{"namespace":"default","name":"foo","candidate":true},
+		{"namespace":"testing","name":"bar","candidate":false,"filter":"labels","reason":"labels don't match selector \"app=foo\""}
+	]`, res.Body.String())
+
+	res = httptest.NewRecorder()
+	handler.ServeHTTP(res, httptest.NewRequest(http.MethodGet, "/debug/explain?pod=testing/bar&output=table", nil))
+	suite.Equal(http.StatusOK, res.Code)
+	suite.Contains(res.Body.String(), `testing     bar    false       labels   labels don't match selector "app=foo"`)
+	suite.NotContains(res.Body.String(), "default")
+
+	res = httptest.NewRecorder()
+	handler.ServeHTTP(res, httptest.NewRequest(http.MethodGet, "/debug/explain?pod=default/baz", nil))
+	suite.Equal(http.StatusNotFound, res.Code)
+
+	res = httptest.NewRecorder()
+	handler.ServeHTTP(res, httptest.NewRequest(http.MethodGet, "/debug/explain?output=yaml", nil))
+	suite.Equal(http.StatusBadRequest, res.Code)
+}
+
+func TestExplainSuite(t *testing.T) {
+	suite.Run(t, new(ExplainSuite))
+}
@@ -0,0 +1,30 @@
+package api
+
+import (
+	"net/http"
+	"strconv"
+
+	"github.com/linki/chaoskube/audit"
+)
+
+// HistoryHandler returns an http.Handler that responds with the recent intervals recorded
+// by history as JSON, most recent first. The query parameter limit restricts the number of
+// intervals.
+func HistoryHandler(history *audit.MemorySink) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		records := history.Records()
+
+		if value := r.URL.Query().Get("limit"); value != "" {
+			limit, err := strconv.Atoi(value)
+			if err != nil || limit < 0 {
+				writeJSON(w, http.StatusBadRequest, errorResponse{Error: "invalid limit: " + value})
+				return
+			}
+			if limit < len(records) {
+				records = records[:limit]
+			}
+		}
+
+		writeJSON(w, http.StatusOK, records)
+	})
+}
@@ -0,0 +1,72 @@
+package audit
+
+import (
+	"context"
+	"time"
+
+	multierror "github.com/hashicorp/go-multierror"
+)
+
+// Outcomes of an interval.
+const (
+	// OutcomeTerminated means that all victims were terminated, or would have been in dry-run mode.
+	OutcomeTerminated = "terminated"
+	// OutcomeSkipped means that no pod was terminated on purpose, see Record.SkipReason.
+	OutcomeSkipped = "skipped"
+	// OutcomeFailed means that the interval ended with errors, see Record.Errors.
+	OutcomeFailed = "failed"
+)
+
+// Record describes the decision chaoskube took in a single interval.
+type Record struct {
+	// the time the interval started
+	Time time.Time `json:"time"`
+	// a hash of the settings that decide which pods are terminated
+	ConfigHash string `json:"configHash"`
+	// the number of pods that were candidates for termination
+	Candidates int `json:"candidates"`
+	// the pods that were picked for termination
+	Victims []Victim `json:"victims,omitempty"`
+	// the name of the terminator used
+	Terminator string `json:"terminator"`
+	// whether dry-run mode was enabled
+	DryRun bool `json:"dryRun"`
+	// one of OutcomeTerminated, OutcomeSkipped or OutcomeFailed
+	Outcome string `json:"outcome"`
+	// why the interval was skipped
+	SkipReason string `json:"skipReason,omitempty"`
+	// the errors that occurred during the interval
+	Errors []string `json:"errors,omitempty"`
+}
+
+// Victim describes a pod that was picked for termination.
+type Victim struct {
+	// the namespace of the pod
+	Namespace string `json:"namespace"`
+	// the name of the pod
+	Name string `json:"name"`
+	// why terminating the pod failed, empty if it succeeded
+	Error string `json:"error,omitempty"`
+	// whether the pod was terminated in dry-run mode because its namespace is armed
+	Armed bool `json:"armed,omitempty"`
+}
+
+// Sink is the interface for destinations of the audit trail.
+type Sink interface {
+	// Write appends the given record to the audit trail.
+	Write(ctx context.Context, record Record) error
+}
+
+// Sinks writes records to several sinks.
+type Sinks []Sink
+
+// Write appends the given record to all sinks and returns the combined errors.
+func (s Sinks) Write(ctx context.Context, record Record) error {
+	var result *multierror.Error
+	for _, sink := range s {
+		if err := sink.Write(ctx, record); err != nil {
+			result = multierror.Append(result, err)
+		}
+	}
+	return result.ErrorOrNil()
+}
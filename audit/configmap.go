@@ -0,0 +1,98 @@
+package audit
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+
+	v1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/util/retry"
+)
+
+// KeyRecords is the ConfigMap key holding the records as JSON lines, oldest first.
+const KeyRecords = "records"
+
+// DefaultConfigMapSize is the default number of records kept in a ConfigMap.
+const DefaultConfigMapSize = 100
+
+// ConfigMapSink keeps the most recent records in a ConfigMap acting as a ring buffer.
+// The ConfigMap is created if it doesn't exist.
+type ConfigMapSink struct {
+	client    kubernetes.Interface
+	namespace string
+	name      string
+	size      int
+}
+
+// NewConfigMapSink creates and returns a ConfigMapSink that keeps the given number of records.
+func NewConfigMapSink(client kubernetes.Interface, namespace, name string, size int) *ConfigMapSink {
+	if size <= 0 {
+		size = DefaultConfigMapSize
+	}
+
+	return &ConfigMapSink{
+		client:    client,
+		namespace: namespace,
+		name:      name,
+		size:      size,
+	}
+}
+
+// Write appends the record to the ConfigMap and drops the oldest records beyond its size.
+func (s *ConfigMapSink) Write(ctx context.Context, record Record) error {
+	line, err := json.Marshal(record)
+	if err != nil {
+		return err
+	}
+
+	return retry.RetryOnConflict(retry.DefaultRetry, func() error {
+		configMaps := s.client.CoreV1().ConfigMaps(s.namespace)
+
+		configMap, err := configMaps.Get(ctx, s.name, metav1.GetOptions{})
+		if apierrors.IsNotFound(err) {
+			configMap = &v1.ConfigMap{
+				ObjectMeta: metav1.ObjectMeta{Namespace: s.namespace, Name: s.name},
+				Data:       map[string]string{KeyRecords: s.append("", line)},
+			}
+			_, err = configMaps.Create(ctx, configMap, metav1.CreateOptions{})
+			if apierrors.IsAlreadyExists(err) {
+				// someone else created it in the meantime, treat it like a conflicting update
+				return apierrors.NewConflict(v1.Resource("configmaps"), s.name, err)
+			}
+			return err
+		}
+		if err != nil {
+			return err
+		}
+
+		if configMap.Data == nil {
+			configMap.Data = map[string]string{}
+		}
+		configMap.Data[KeyRecords] = s.append(configMap.Data[KeyRecords], line)
+
+		_, err = configMaps.Update(ctx, configMap, metav1.UpdateOptions{})
+		return err
+	})
+}
+
+// append adds the line to the given JSON lines and keeps at most size lines.
+func (s *ConfigMapSink) append(records string, line []byte) string {
+	lines := bytes.Split([]byte(records), []byte("\n"))
+
+	kept := [][]byte{}
+	for _, l := range lines {
+		if len(l) > 0 {
+			kept = append(kept, l)
+		}
+	}
+	kept = append(kept, line)
+
+	if len(kept) > s.size {
+		kept = kept[len(kept)-s.size:]
+	}
+
+	return string(bytes.Join(kept, []byte("\n"))) + "\n"
+}
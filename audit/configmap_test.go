@@ -0,0 +1,41 @@
+package audit
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes/fake"
+
+	"github.com/stretchr/testify/suite"
+)
+
+type ConfigMapSinkSuite struct {
+	suite.Suite
+}
+
+func (suite *ConfigMapSinkSuite) TestWrite() {
+	client := fake.NewSimpleClientset()
+	sink := NewConfigMapSink(client, "chaoskube", "audit", 2)
+
+	for _, hash := range []string{"a", "b", "c"} {
+		suite.Require().NoError(sink.Write(context.Background(), Record{Time: now, ConfigHash: hash, Outcome: OutcomeTerminated}))
+	}
+
+	configMap, err := client.CoreV1().ConfigMaps("chaoskube").Get(context.Background(), "audit", metav1.GetOptions{})
+	suite.Require().NoError(err)
+
+	lines := strings.Split(strings.TrimSuffix(configMap.Data[KeyRecords], "\n"), "\n")
+	suite.Require().Len(lines, 2)
+	suite.Contains(lines[0], `"configHash":"b"`)
+	suite.Contains(lines[1], `"configHash":"c"`)
+}
+
+func (suite *ConfigMapSinkSuite) TestDefaultSize() {
+	suite.Equal(DefaultConfigMapSize, NewConfigMapSink(fake.NewSimpleClientset(), "chaoskube", "audit", 0).size)
+}
+
+func TestConfigMapSinkSuite(t *testing.T) {
+	suite.Run(t, new(ConfigMapSinkSuite))
+}
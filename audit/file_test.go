@@ -0,0 +1,61 @@
+package audit
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/suite"
+)
+
+type FileSinkSuite struct {
+	suite.Suite
+}
+
+var now = time.Date(1869, 9, 24, 15, 4, 5, 0, time.UTC)
+
+func (suite *FileSinkSuite) TestWrite() {
+	path := filepath.Join(suite.T().TempDir(), "audit.jsonl")
+
+	// an existing file is appended to
+	suite.Require().NoError(os.WriteFile(path, []byte("{}\n"), 0o644))
+
+	sink, err := NewFileSink(path)
+	suite.Require().NoError(err)
+
+	suite.Require().NoError(sink.Write(context.Background(), Record{
+		Time:       now,
+		ConfigHash: "abc",
+		Candidates: 2,
+		Victims:    []Victim{{Namespace: "default", Name: "foo"}},
+		Terminator: "DeletePod",
+		Outcome:    OutcomeTerminated,
+	}))
+	suite.Require().NoError(sink.Write(context.Background(), Record{
+		Time:       now,
+		ConfigHash: "abc",
+		Terminator: "DeletePod",
+		DryRun:     true,
+		Outcome:    OutcomeSkipped,
+		SkipReason: "weekday",
+	}))
+	suite.Require().NoError(sink.Close())
+
+	content, err := os.ReadFile(path)
+	suite.Require().NoError(err)
+	suite.Equal("{}\n"+
+		`{"time":"1869-09-24T15:04:05Z","configHash":"abc","candidates":2,"victims":[{"namespace":"default","name":"foo"}],"terminator":"DeletePod","dryRun":false,"outcome":"terminated"}`+"\n"+
+		`{"time":"1869-09-24T15:04:05Z","configHash":"abc","candidates":0,"terminator":"DeletePod","dryRun":true,"outcome":"skipped","skipReason":"weekday"}`+"\n",
+		string(content))
+}
+
+func (suite *FileSinkSuite) TestNewFileSinkInvalidPath() {
+	_, err := NewFileSink(filepath.Join(suite.T().TempDir(), "missing", "audit.jsonl"))
+	suite.Error(err)
+}
+
+func TestFileSinkSuite(t *testing.T) {
+	suite.Run(t, new(FileSinkSuite))
+}
@@ -0,0 +1,44 @@
+package audit
+
+import (
+	"context"
+	"sync"
+)
+
+// MemorySink keeps the most recent records in memory, e.g. to show them on a dashboard.
+type MemorySink struct {
+	mu      sync.RWMutex
+	size    int
+	records []Record
+}
+
+// NewMemorySink creates and returns a MemorySink that keeps the given number of records.
+func NewMemorySink(size int) *MemorySink {
+	return &MemorySink{size: size}
+}
+
+// Write adds the record and drops the oldest record if the sink is full.
+func (s *MemorySink) Write(_ context.Context, record Record) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.records = append(s.records, record)
+	if len(s.records) > s.size {
+		s.records = s.records[len(s.records)-s.size:]
+	}
+
+	return nil
+}
+
+// Records returns the kept records, most recent first.
+func (s *MemorySink) Records() []Record {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	records := make([]Record, 0, len(s.records))
+	for i := len(s.records) - 1; i >= 0; i-- {
+		records = append(records, s.records[i])
+	}
+
+	return records
+}
@@ -0,0 +1,27 @@
+package audit
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+)
+
+type MemorySinkSuite struct {
+	suite.Suite
+}
+
+func (suite *MemorySinkSuite) TestRecords() {
+	sink := NewMemorySink(2)
+	suite.Empty(sink.Records())
+
+	for _, hash := range []string{"a", "b", "c"} {
+		suite.Require().NoError(sink.Write(context.Background(), Record{ConfigHash: hash}))
+	}
+
+	suite.Equal([]Record{{ConfigHash: "c"}, {ConfigHash: "b"}}, sink.Records())
+}
+
+func TestMemorySinkSuite(t *testing.T) {
+	suite.Run(t, new(MemorySinkSuite))
+}
@@ -0,0 +1,136 @@
+package chaoskube
+
+import (
+	"context"
+	"time"
+
+	log "github.com/sirupsen/logrus"
+	"go.opentelemetry.io/otel/trace"
+
+	v1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/linki/chaoskube/audit"
+	"github.com/linki/chaoskube/metrics"
+	"github.com/linki/chaoskube/notifier"
+)
+
+// cancelReasons explain the cancellation of announced terminations for each reason to skip
+// an interval that may come up during the warning period.
+var cancelReasons = map[string]string{
+	metrics.SkipReasonPaused:      "chaos was paused",
+	metrics.SkipReasonWeekday:     "quiet time began",
+	metrics.SkipReasonTimeOfDay:   "quiet time began",
+	metrics.SkipReasonDayOfYear:   "quiet time began",
+	metrics.SkipReasonHealthCheck: "health check failed",
+}
+
+// announce warns about the upcoming termination of the victims, waits for the warning period
+// and returns the victims that are still eligible for termination afterwards. Terminations are
+// cancelled if chaos was paused, a quiet time began or a health check failed in the meantime
+// or if a victim no longer passes the filters, e.g. because it opted out with the
+// AnnotationOptOut annotation.
+func (c *Chaoskube) announce(ctx context.Context, record *audit.Record, victims []v1.Pod) (_ []v1.Pod, err error) {
+	ctx, span := c.tracer().Start(ctx, "Announce", trace.WithAttributes(attributeVictims.Int(len(victims))))
+	defer func() { endSpan(span, err) }()
+
+	for i := range victims {
+		c.Logger.WithFields(log.Fields{
+			"namespace": victims[i].Namespace,
+			"name":      victims[i].Name,
+			"in":        c.WarningPeriod,
+		}).Info("announcing pod termination")
+		c.notify(ctx, notifier.Event{Type: notifier.EventTerminationAnnounced, Pod: &victims[i], Duration: c.WarningPeriod, DryRun: record.DryRun})
+	}
+
+	wait := c.sleep
+	if wait == nil {
+		wait = sleep
+	}
+	if err := wait(ctx, c.WarningPeriod); err != nil {
+		return nil, err
+	}
+
+	reason, err := c.holdReason(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if reason != "" {
+		for i := range victims {
+			c.cancel(ctx, record, victims[i], cancelReasons[reason])
+		}
+		c.skipInterval(ctx, record, reason)
+		return nil, nil
+	}
+
+	remaining := []v1.Pod{}
+	for _, victim := range victims {
+		pod, reason, err := c.recheck(ctx, victim)
+		if err != nil {
+			return nil, err
+		}
+		if pod == nil {
+			c.cancel(ctx, record, victim, reason)
+			continue
+		}
+		remaining = append(remaining, *pod)
+	}
+
+	if len(remaining) == 0 {
+		c.skipInterval(ctx, record, metrics.SkipReasonCancelled)
+	}
+
+	return remaining, nil
+}
+
+// cancel reports that the announced termination of victim won't happen for the given reason.
+func (c *Chaoskube) cancel(ctx context.Context, record *audit.Record, victim v1.Pod, reason string) {
+	c.Logger.WithFields(log.Fields{
+		"namespace": victim.Namespace,
+		"name":      victim.Name,
+		"reason":    reason,
+	}).Info("cancelling pod termination")
+	c.notify(ctx, notifier.Event{Type: notifier.EventTerminationCancelled, Pod: &victim, Reason: reason, DryRun: record.DryRun})
+}
+
+// recheck fetches the current state of the victim and runs it through the filters again.
+// It returns the up-to-date pod or, if it's no longer a candidate, nil and the reason why not.
+func (c *Chaoskube) recheck(ctx context.Context, victim v1.Pod) (*v1.Pod, string, error) {
+	pod, err := c.Client.CoreV1().Pods(victim.Namespace).Get(ctx, victim.Name, metav1.GetOptions{})
+	if apierrors.IsNotFound(err) {
+		return nil, "pod is gone", nil
+	}
+	if err != nil {
+		return nil, "", err
+	}
+	if pod.UID != victim.UID {
+		return nil, "pod was replaced", nil
+	}
+
+	pods := []v1.Pod{*pod}
+	for _, filter := range append([]Filter{LabelFilter{Labels: c.Labels}}, c.filters()...) {
+		passed, reason, err := filter.Filter(ctx, pods)
+		if err != nil {
+			return nil, "", err
+		}
+		if len(passed) == 0 {
+			return nil, reason, nil
+		}
+	}
+
+	return pod, "", nil
+}
+
+// sleep waits for the given duration or until ctx is cancelled, whichever happens first.
+func sleep(ctx context.Context, d time.Duration) error {
+	timer := time.NewTimer(d)
+	defer timer.Stop()
+
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-timer.C:
+		return nil
+	}
+}
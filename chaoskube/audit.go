@@ -0,0 +1,76 @@
+package chaoskube
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+
+	multierror "github.com/hashicorp/go-multierror"
+
+	"github.com/linki/chaoskube/audit"
+	"github.com/linki/chaoskube/util"
+)
+
+// ConfigHash returns a short hash of the settings that decide which pods are terminated and when.
+// It allows to tell from the audit trail whether the configuration changed between runs.
+func (c *Chaoskube) ConfigHash() string {
+	hash := sha256.New()
+
+	fmt.Fprintf(hash, "labels=%s\n", c.Labels)
+	fmt.Fprintf(hash, "annotations=%s\n", c.Annotations)
+	fmt.Fprintf(hash, "kinds=%s\n", c.Kinds)
+	fmt.Fprintf(hash, "namespaces=%s\n", c.Namespaces)
+	fmt.Fprintf(hash, "namespaceLabels=%s\n", c.NamespaceLabels)
+	fmt.Fprintf(hash, "includedPodNames=%s\n", regexpString(c.IncludedPodNames))
+	fmt.Fprintf(hash, "excludedPodNames=%s\n", regexpString(c.ExcludedPodNames))
+	fmt.Fprintf(hash, "excludedWeekdays=%v\n", c.ExcludedWeekdays)
+	fmt.Fprintf(hash, "excludedTimesOfDay=%v\n", c.ExcludedTimesOfDay)
+	fmt.Fprintf(hash, "excludedDaysOfYear=%s\n", util.FormatDays(c.ExcludedDaysOfYear))
+	fmt.Fprintf(hash, "timezone=%s\n", c.Timezone)
+	fmt.Fprintf(hash, "minimumAge=%s\n", c.MinimumAge)
+	fmt.Fprintf(hash, "maxKill=%d\n", c.MaxKill)
+	fmt.Fprintf(hash, "clientNamespaceScope=%s\n", c.ClientNamespaceScope)
+	if c.ArmedNamespaces != nil {
+		fmt.Fprintf(hash, "armedNamespaces=%s\n", c.ArmedNamespaces)
+	}
+	if c.ArmedNamespaceLabels != nil {
+		fmt.Fprintf(hash, "armedNamespaceLabels=%s\n", c.ArmedNamespaceLabels)
+	}
+	if c.ArmedNamespaceAnnotation {
+		fmt.Fprintf(hash, "armedNamespaceAnnotation=true\n")
+	}
+	for _, filter := range c.filters() {
+		fmt.Fprintf(hash, "filter=%s\n", filter.Name())
+	}
+
+	return hex.EncodeToString(hash.Sum(nil))[:16]
+}
+
+// audit completes the record of a run with its outcome and writes it to the audit sink.
+func (c *Chaoskube) audit(ctx context.Context, record *audit.Record, err error) {
+	if c.AuditSink == nil {
+		return
+	}
+
+	switch {
+	case err != nil:
+		record.Outcome = audit.OutcomeFailed
+		if merr, ok := err.(*multierror.Error); ok {
+			for _, e := range merr.Errors {
+				record.Errors = append(record.Errors, e.Error())
+			}
+		} else {
+			record.Errors = []string{err.Error()}
+		}
+	case record.SkipReason != "":
+		record.Outcome = audit.OutcomeSkipped
+	default:
+		record.Outcome = audit.OutcomeTerminated
+	}
+
+	// write the record even if the run was canceled
+	if err := c.AuditSink.Write(context.WithoutCancel(ctx), *record); err != nil {
+		c.Logger.WithField("err", err).Error("failed to write audit record")
+	}
+}
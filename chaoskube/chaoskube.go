@@ -5,25 +5,31 @@ import (
 	"errors"
 	"fmt"
 	"regexp"
+	"sync"
 	"time"
 
 	multierror "github.com/hashicorp/go-multierror"
 
 	log "github.com/sirupsen/logrus"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/trace"
 
 	v1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/labels"
 	"k8s.io/apimachinery/pkg/selection"
 	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/uuid"
 	"k8s.io/client-go/kubernetes"
 	"k8s.io/client-go/kubernetes/scheme"
 	typedcorev1 "k8s.io/client-go/kubernetes/typed/core/v1"
 	"k8s.io/client-go/tools/record"
-	"k8s.io/client-go/tools/reference"
 
+	"github.com/linki/chaoskube/audit"
+	"github.com/linki/chaoskube/health"
 	"github.com/linki/chaoskube/metrics"
 	"github.com/linki/chaoskube/notifier"
+	"github.com/linki/chaoskube/pause"
 	"github.com/linki/chaoskube/terminator"
 	"github.com/linki/chaoskube/util"
 )
@@ -62,6 +68,14 @@ type Chaoskube struct {
 	Terminator terminator.Terminator
 	// dry run will not allow any pod terminations
 	DryRun bool
+	// a namespace selector for namespaces whose pods are terminated even in dry-run mode, none if empty
+	ArmedNamespaces labels.Selector
+	// a namespace label selector for namespaces whose pods are terminated even in dry-run mode, none if empty
+	ArmedNamespaceLabels labels.Selector
+	// whether namespaces can arm themselves with the AnnotationArmed annotation
+	ArmedNamespaceAnnotation bool
+	// a cache to look up the labels and annotations of armed namespaces in
+	NamespaceCache *util.NamespaceCache
 	// grace period to terminate the pods
 	GracePeriod time.Duration
 	// event recorder allows to publish events to Kubernetes
@@ -74,9 +88,45 @@ type Chaoskube struct {
 	Notifier notifier.Notifier
 	// namespace scope for the Kubernetes client
 	ClientNamespaceScope string
+	// health checks that must pass before any pod is terminated
+	HealthCheckers []health.Checker
+	// a switch that pauses all terminations while it's on
+	PauseSwitch *pause.Switch
+	// the pipeline of filters that turns all pods into candidates, defaults to DefaultFilters if nil
+	Filters []Filter
+	// the names of the built-in filters to apply in the given order if Filters is nil, all of them if empty
+	FilterNames []string
+	// the optional labels attached to the termination metrics, none if nil
+	MetricLabels *metrics.TerminationLabels
+	// a tracer to record the spans of each run with, the global tracer if nil
+	Tracer trace.Tracer
+	// a destination for the audit trail of each run, none if nil
+	AuditSink audit.Sink
+	// how long to wait for the owner of a terminated pod to recover, disabled if zero
+	RecoveryTimeout time.Duration
+	// how long to announce a termination before it happens, disabled if zero
+	WarningPeriod time.Duration
+	// the name of the chaos experiment, included in events
+	Experiment string
+	// the pod chaoskube runs in, skipped intervals are recorded on it if set
+	Self *v1.ObjectReference
+
+	// recoveries tracks the running recovery watchers
+	recoveries sync.WaitGroup
+	// sleep waits for the given duration unless the context is cancelled first, defaults to sleep if nil
+	sleep func(ctx context.Context, d time.Duration) error
+
+	// runMu serializes runs of TerminateVictims
+	runMu sync.Mutex
+	// mu guards settings that can be changed at runtime
+	mu sync.RWMutex
+	// dryRunSet is true once dry-run mode was changed at runtime, which overrides armed namespaces
+	dryRunSet bool
 }
 
 var (
+	// ErrRunInProgress is returned by Trigger while another run is in progress
+	ErrRunInProgress = errors.New("another run is in progress")
 	// errPodNotFound is returned when no victim could be found
 	errPodNotFound = errors.New("pod not found")
 	// msgVictimNotFound is the log message when no victim was found
@@ -87,6 +137,10 @@ var (
 	msgTimeOfDayExcluded = "time of day excluded"
 	// msgDayOfYearExcluded is the log message when termination is suspended due to the day of year filter
 	msgDayOfYearExcluded = "day of year excluded"
+	// msgPaused is the log message when termination is suspended because chaoskube is paused
+	msgPaused = "chaos paused"
+	// msgHealthCheckFailed is the log message when termination is suspended due to a failing health check
+	msgHealthCheckFailed = "health check failed"
 )
 
 // New returns a new instance of Chaoskube. It expects:
@@ -97,13 +151,10 @@ var (
 // * a logger implementing logrus.FieldLogger to send log output to
 // * what specific terminator to use to imbue chaos on victim pods
 // * whether to enable/disable dry-run mode
+//
+// Deprecated: New breaks whenever a setting is added, use NewWithOptions instead.
 func New(client kubernetes.Interface, labels, annotations, kinds, namespaces, namespaceLabels labels.Selector, includedPodNames, excludedPodNames *regexp.Regexp, excludedWeekdays []time.Weekday, excludedTimesOfDay []util.TimePeriod, excludedDaysOfYear []time.Time, timezone *time.Location, minimumAge time.Duration, logger log.FieldLogger, dryRun bool, terminator terminator.Terminator, maxKill int, notifier notifier.Notifier, clientNamespaceScope string) *Chaoskube {
-	broadcaster := record.NewBroadcaster()
-	broadcaster.StartRecordingToSink(&typedcorev1.EventSinkImpl{Interface: client.CoreV1().Events(clientNamespaceScope)})
-	recorder := broadcaster.NewRecorder(scheme.Scheme, v1.EventSource{Component: "chaoskube"})
-
-	return &Chaoskube{
-		Client:               client,
+	config := Config{
 		Labels:               labels,
 		Annotations:          annotations,
 		Kinds:                kinds,
@@ -116,15 +167,54 @@ func New(client kubernetes.Interface, labels, annotations, kinds, namespaces, na
 		ExcludedDaysOfYear:   excludedDaysOfYear,
 		Timezone:             timezone,
 		MinimumAge:           minimumAge,
-		Logger:               logger,
 		DryRun:               dryRun,
-		Terminator:           terminator,
-		EventRecorder:        recorder,
-		Now:                  time.Now,
 		MaxKill:              maxKill,
-		Notifier:             notifier,
 		ClientNamespaceScope: clientNamespaceScope,
 	}
+
+	return newChaoskube(client, config, logger, terminator, notifier, newEventRecorder(client, clientNamespaceScope), pause.NewSwitch(logger))
+}
+
+// newChaoskube returns a new instance of Chaoskube with the given settings and collaborators.
+func newChaoskube(client kubernetes.Interface, config Config, logger log.FieldLogger, terminator terminator.Terminator, notifier notifier.Notifier, recorder record.EventRecorder, pauseSwitch *pause.Switch) *Chaoskube {
+	return &Chaoskube{
+		Client:                   client,
+		Labels:                   config.Labels,
+		Annotations:              config.Annotations,
+		Kinds:                    config.Kinds,
+		Namespaces:               config.Namespaces,
+		NamespaceLabels:          config.NamespaceLabels,
+		IncludedPodNames:         config.IncludedPodNames,
+		ExcludedPodNames:         config.ExcludedPodNames,
+		ExcludedWeekdays:         config.ExcludedWeekdays,
+		ExcludedTimesOfDay:       config.ExcludedTimesOfDay,
+		ExcludedDaysOfYear:       config.ExcludedDaysOfYear,
+		Timezone:                 config.Timezone,
+		MinimumAge:               config.MinimumAge,
+		Logger:                   logger,
+		DryRun:                   config.DryRun,
+		ArmedNamespaces:          config.ArmedNamespaces,
+		ArmedNamespaceLabels:     config.ArmedNamespaceLabels,
+		ArmedNamespaceAnnotation: config.ArmedNamespaceAnnotation,
+		NamespaceCache:           util.NewNamespaceCache(client, util.DefaultNamespaceCacheTTL),
+		Terminator:               terminator,
+		EventRecorder:            recorder,
+		Now:                      time.Now,
+		MaxKill:                  config.MaxKill,
+		FilterNames:              config.FilterNames,
+		Notifier:                 notifier,
+		ClientNamespaceScope:     config.ClientNamespaceScope,
+		PauseSwitch:              pauseSwitch,
+		Tracer:                   otel.Tracer(tracerName),
+		sleep:                    sleep,
+	}
+}
+
+// newEventRecorder returns an event recorder that publishes events to Kubernetes.
+func newEventRecorder(client kubernetes.Interface, namespace string) record.EventRecorder {
+	broadcaster := record.NewBroadcaster()
+	broadcaster.StartRecordingToSink(&typedcorev1.EventSinkImpl{Interface: client.CoreV1().Events(namespace)})
+	return broadcaster.NewRecorder(scheme.Scheme, v1.EventSource{Component: "chaoskube"})
 }
 
 // Run continuously picks and terminates a victim pod at a given interval
@@ -147,49 +237,238 @@ func (c *Chaoskube) Run(ctx context.Context, next <-chan time.Time) {
 }
 
 // TerminateVictims picks and deletes a victim.
-// It respects the configured excluded weekdays, times of day and days of a year filters.
+// It respects the pause switch, the configured excluded weekdays, times of day and
+// days of a year filters as well as the configured health checks.
+// Runs are serialized, so it waits for a run in progress, e.g. during its warning period.
 func (c *Chaoskube) TerminateVictims(ctx context.Context) error {
-	now := c.Now().In(c.Timezone)
+	c.runMu.Lock()
+	defer c.runMu.Unlock()
 
-	for _, wd := range c.ExcludedWeekdays {
-		if wd == now.Weekday() {
-			c.Logger.WithField("weekday", now.Weekday()).Debug(msgWeekdayExcluded)
-			return nil
-		}
+	return c.terminateVictims(ctx)
+}
+
+// Trigger is like TerminateVictims but returns ErrRunInProgress right away instead of waiting
+// for a run in progress, which may take as long as the warning period.
+func (c *Chaoskube) Trigger(ctx context.Context) error {
+	if !c.runMu.TryLock() {
+		return ErrRunInProgress
 	}
+	defer c.runMu.Unlock()
 
-	for _, tp := range c.ExcludedTimesOfDay {
-		if tp.Includes(now) {
-			c.Logger.WithField("timeOfDay", now.Format(util.Kitchen24)).Debug(msgTimeOfDayExcluded)
-			return nil
-		}
+	return c.terminateVictims(ctx)
+}
+
+// terminateVictims implements TerminateVictims, the caller must hold runMu.
+func (c *Chaoskube) terminateVictims(ctx context.Context) (err error) {
+	ctx, span := c.tracer().Start(ctx, "TerminateVictims")
+	defer func() { endSpan(span, err) }()
+
+	record := &audit.Record{
+		Time:       c.Now(),
+		ConfigHash: c.ConfigHash(),
+		Terminator: terminator.Name(c.Terminator),
+		DryRun:     c.IsDryRun(),
 	}
+	defer func() { c.audit(ctx, record, err) }()
 
-	for _, d := range c.ExcludedDaysOfYear {
-		if d.Day() == now.Day() && d.Month() == now.Month() {
-			c.Logger.WithField("dayOfYear", now.Format(util.YearDay)).Debug(msgDayOfYearExcluded)
-			return nil
-		}
+	c.notify(ctx, notifier.Event{Type: notifier.EventIntervalStarted, DryRun: record.DryRun})
+
+	reason, err := c.holdReason(ctx)
+	if err != nil {
+		return err
+	}
+	if reason != "" {
+		c.skipInterval(ctx, record, reason)
+		return nil
+	}
+
+	candidates, stages, err := c.candidates(ctx)
+	if err != nil {
+		return err
+	}
+	for _, stage := range stages {
+		metrics.Candidates.WithLabelValues(stage.filter).Set(float64(stage.count))
 	}
+	record.Candidates = len(candidates)
 
-	victims, err := c.Victims(ctx)
+	victims, err := c.selectVictims(ctx, candidates)
+	metrics.Victims.Set(float64(len(victims)))
 	if err == errPodNotFound {
 		c.Logger.Debug(msgVictimNotFound)
+		c.skipInterval(ctx, record, metrics.SkipReasonNoVictim)
 		return nil
 	}
 	if err != nil {
 		return err
 	}
 
+	c.notify(ctx, notifier.Event{
+		Type:       notifier.EventVictimsSelected,
+		Victims:    victims,
+		Candidates: len(candidates),
+		DryRun:     record.DryRun,
+	})
+
+	if c.WarningPeriod > 0 {
+		victims, err = c.announce(ctx, record, victims)
+		if err != nil {
+			return err
+		}
+	}
+
+	return c.terminate(ctx, record, victims)
+}
+
+// terminate deletes the given victims and adds them to the audit record.
+func (c *Chaoskube) terminate(ctx context.Context, record *audit.Record, victims []v1.Pod) error {
 	var result *multierror.Error
+	results := make([]notifier.Result, 0, len(victims))
 	for _, victim := range victims {
-		err = c.DeletePod(ctx, victim)
+		dryRun := c.isDryRun(ctx, victim)
+		armed := record.DryRun && !dryRun
+
+		err := c.deletePod(ctx, victim, dryRun)
 		result = multierror.Append(result, err)
+		results = append(results, notifier.Result{Pod: victim, Error: err, Armed: armed})
+
+		entry := audit.Victim{Namespace: victim.Namespace, Name: victim.Name, Armed: armed}
+		if err != nil {
+			entry.Error = err.Error()
+		}
+		record.Victims = append(record.Victims, entry)
+	}
+
+	if len(victims) > 0 {
+		c.notify(ctx, notifier.Event{
+			Type:    notifier.EventIntervalCompleted,
+			Victims: victims,
+			Results: results,
+			DryRun:  record.DryRun,
+		})
 	}
 
 	return result.ErrorOrNil()
 }
 
+// skipInterval records that no pod is terminated in the current interval for the given reason.
+func (c *Chaoskube) skipInterval(ctx context.Context, record *audit.Record, reason string) {
+	record.SkipReason = reason
+	metrics.IntervalsSkippedTotal.WithLabelValues(reason).Inc()
+	trace.SpanFromContext(ctx).SetAttributes(attributeSkipReason.String(reason))
+
+	c.recordSkip(reason)
+	c.notify(ctx, notifier.Event{Type: notifier.EventIntervalSkipped, Reason: reason, DryRun: record.DryRun})
+}
+
+// holdReason returns why no pods may be terminated right now, i.e. the paused, quiet time or
+// health check skip reason, or an empty string if chaos may go ahead.
+func (c *Chaoskube) holdReason(ctx context.Context) (string, error) {
+	if status := c.PauseSwitch.Status(); status.Paused {
+		c.Logger.WithFields(log.Fields{
+			"sources": status.Sources,
+			"until":   status.Until,
+		}).Debug(msgPaused)
+		return metrics.SkipReasonPaused, nil
+	}
+
+	now := c.Now().In(c.Timezone)
+
+	if c.isExcludedWeekday(now) {
+		c.Logger.WithField("weekday", now.Weekday()).Debug(msgWeekdayExcluded)
+		return metrics.SkipReasonWeekday, nil
+	}
+
+	if c.isExcludedTimeOfDay(now) {
+		c.Logger.WithField("timeOfDay", now.Format(util.Kitchen24)).Debug(msgTimeOfDayExcluded)
+		return metrics.SkipReasonTimeOfDay, nil
+	}
+
+	if c.isExcludedDayOfYear(now) {
+		c.Logger.WithField("dayOfYear", now.Format(util.YearDay)).Debug(msgDayOfYearExcluded)
+		return metrics.SkipReasonDayOfYear, nil
+	}
+
+	for _, checker := range c.HealthCheckers {
+		result, err := c.checkHealth(ctx, checker)
+		if err != nil {
+			return "", err
+		}
+		if result != nil {
+			c.Logger.WithFields(log.Fields{
+				"check":  result.Check,
+				"reason": result.Message,
+			}).Debug(msgHealthCheckFailed)
+			metrics.HealthChecksFailedTotal.WithLabelValues(result.Check).Inc()
+			if result.Object != nil {
+				c.EventRecorder.Event(result.Object, v1.EventTypeWarning, eventReasonSkipped, "Chaos was skipped by chaoskube: "+result.Message)
+			}
+			return metrics.SkipReasonHealthCheck, nil
+		}
+	}
+
+	return "", nil
+}
+
+// QuietTime returns why terminations are currently suspended by the configured quiet times,
+// i.e. one of the weekday, time of day or day of year skip reasons, or an empty string if they aren't.
+func (c *Chaoskube) QuietTime() string {
+	now := c.Now().In(c.Timezone)
+
+	switch {
+	case c.isExcludedWeekday(now):
+		return metrics.SkipReasonWeekday
+	case c.isExcludedTimeOfDay(now):
+		return metrics.SkipReasonTimeOfDay
+	case c.isExcludedDayOfYear(now):
+		return metrics.SkipReasonDayOfYear
+	default:
+		return ""
+	}
+}
+
+// isExcludedWeekday returns true iff the given time falls on one of the excluded weekdays.
+func (c *Chaoskube) isExcludedWeekday(now time.Time) bool {
+	for _, wd := range c.ExcludedWeekdays {
+		if wd == now.Weekday() {
+			return true
+		}
+	}
+	return false
+}
+
+// isExcludedTimeOfDay returns true iff the given time falls into one of the excluded time periods.
+func (c *Chaoskube) isExcludedTimeOfDay(now time.Time) bool {
+	for _, tp := range c.ExcludedTimesOfDay {
+		if tp.Includes(now) {
+			return true
+		}
+	}
+	return false
+}
+
+// isExcludedDayOfYear returns true iff the given time falls on one of the excluded days of a year.
+func (c *Chaoskube) isExcludedDayOfYear(now time.Time) bool {
+	for _, d := range c.ExcludedDaysOfYear {
+		if d.Day() == now.Day() && d.Month() == now.Month() {
+			return true
+		}
+	}
+	return false
+}
+
+// checkHealth runs a single health check in its own span.
+func (c *Chaoskube) checkHealth(ctx context.Context, checker health.Checker) (*health.Result, error) {
+	ctx, span := c.tracer().Start(ctx, "HealthCheck")
+
+	result, err := checker.Check(ctx)
+	if result != nil {
+		span.SetAttributes(attributeCheck.String(result.Check))
+	}
+	endSpan(span, err)
+
+	return result, err
+}
+
 // Victims returns up to N pods as configured by MaxKill flag
 func (c *Chaoskube) Victims(ctx context.Context) ([]v1.Pod, error) {
 	pods, err := c.Candidates(ctx)
@@ -197,6 +476,14 @@ func (c *Chaoskube) Victims(ctx context.Context) ([]v1.Pod, error) {
 		return []v1.Pod{}, err
 	}
 
+	return c.selectVictims(ctx, pods)
+}
+
+// selectVictims picks up to MaxKill random pods from the given candidates.
+func (c *Chaoskube) selectVictims(ctx context.Context, pods []v1.Pod) ([]v1.Pod, error) {
+	_, span := c.tracer().Start(ctx, "SelectVictims")
+	defer span.End()
+
 	c.Logger.WithField("count", len(pods)).Debug("found candidates")
 
 	if len(pods) == 0 {
@@ -204,6 +491,7 @@ func (c *Chaoskube) Victims(ctx context.Context) ([]v1.Pod, error) {
 	}
 
 	pods = util.RandomPodSubSlice(pods, c.MaxKill)
+	span.SetAttributes(attributeVictims.Int(len(pods)))
 
 	c.Logger.WithField("count", len(pods)).Debug("found victims")
 	return pods, nil
@@ -212,72 +500,210 @@ func (c *Chaoskube) Victims(ctx context.Context) ([]v1.Pod, error) {
 // Candidates returns the list of pods that are available for termination.
 // It returns all pods that match the configured label, annotation and namespace selectors.
 func (c *Chaoskube) Candidates(ctx context.Context) ([]v1.Pod, error) {
+	pods, _, err := c.candidates(ctx)
+	return pods, err
+}
+
+// stage is the number of pods remaining after a filter.
+type stage struct {
+	filter string
+	count  int
+}
+
+// candidates returns the pods that are available for termination along with the number of
+// pods remaining after each filter. Only TerminateVictims exports the latter as metrics, so
+// that previews of the candidates don't overwrite the values of the last run.
+func (c *Chaoskube) candidates(ctx context.Context) ([]v1.Pod, []stage, error) {
 	listOptions := metav1.ListOptions{LabelSelector: c.Labels.String()}
 
-	podList, err := c.Client.CoreV1().Pods(c.ClientNamespaceScope).List(ctx, listOptions)
+	listCtx, span := c.tracer().Start(ctx, "ListPods", trace.WithAttributes(attributeNamespace.String(c.ClientNamespaceScope)))
+	podList, err := c.Client.CoreV1().Pods(c.ClientNamespaceScope).List(listCtx, listOptions)
+	endSpan(span, err)
 	if err != nil {
-		return nil, err
+		return nil, nil, err
 	}
 
-	pods, err := filterByNamespaces(podList.Items, c.Namespaces)
-	if err != nil {
-		return nil, err
-	}
+	pods := podList.Items
+	stages := []stage{{filter: LabelFilter{}.Name(), count: len(pods)}}
 
-	pods, err = filterPodsByNamespaceLabels(ctx, pods, c.NamespaceLabels, c.Client)
-	if err != nil {
-		return nil, err
+	for _, filter := range c.filters() {
+		pods, err = c.applyFilter(ctx, filter, pods)
+		if err != nil {
+			return nil, nil, err
+		}
+		stages = append(stages, stage{filter: filter.Name(), count: len(pods)})
 	}
 
-	pods, err = filterByKinds(pods, c.Kinds)
-	if err != nil {
-		return nil, err
-	}
+	return pods, stages, nil
+}
 
-	pods = filterByAnnotations(pods, c.Annotations)
-	pods = filterByPhase(pods, v1.PodRunning)
-	pods = filterTerminatingPods(pods)
-	pods = filterByMinimumAge(pods, c.MinimumAge, c.Now())
-	pods = filterByPodName(pods, c.IncludedPodNames, c.ExcludedPodNames)
-	pods = filterByOwnerReference(pods)
+// applyFilter runs a single filter stage in its own span.
+func (c *Chaoskube) applyFilter(ctx context.Context, filter Filter, pods []v1.Pod) ([]v1.Pod, error) {
+	ctx, span := c.tracer().Start(ctx, "Filter "+filter.Name(), trace.WithAttributes(
+		attributeFilter.String(filter.Name()),
+		attributePodsIn.Int(len(pods)),
+	))
 
-	return pods, nil
+	filtered, _, err := filter.Filter(ctx, pods)
+	span.SetAttributes(attributePodsOut.Int(len(filtered)))
+	endSpan(span, err)
+
+	return filtered, err
 }
 
 // DeletePod deletes the given pod with the selected terminator.
-// It will not delete the pod if dry-run mode is enabled.
+// It will not delete the pod if dry-run mode is enabled, unless the pod's namespace is armed.
 func (c *Chaoskube) DeletePod(ctx context.Context, victim v1.Pod) error {
+	return c.deletePod(ctx, victim, c.isDryRun(ctx, victim))
+}
+
+// deletePod deletes the given pod with the selected terminator or only pretends to in dry-run mode.
+func (c *Chaoskube) deletePod(ctx context.Context, victim v1.Pod, dryRun bool) (err error) {
 	c.Logger.WithFields(log.Fields{
 		"namespace": victim.Namespace,
 		"name":      victim.Name,
 	}).Info("terminating pod")
 
-	// return early if we're running in dryRun mode.
-	if c.DryRun {
+	ctx, span := c.tracer().Start(ctx, "DeletePod", trace.WithAttributes(podAttributes(victim)...))
+	defer func() { endSpan(span, err) }()
+
+	ownerKind, ownerName := "", ""
+	if owner := util.OwnerOf(victim); owner != nil {
+		ownerKind, ownerName = owner.Kind, owner.Name
+	}
+	labels := c.MetricLabels.Values(victim.Namespace, ownerKind, ownerName, victim.Spec.NodeName, terminator.Name(c.Terminator))
+
+	// in dryRun mode everything but the termination itself happens as usual.
+	if dryRun {
+		span.SetAttributes(attributeDryRun.Bool(true))
+		metrics.PodsDeletedTotal.WithLabelValues(append(labels, "true")...).Inc()
+		c.recordDryRun(ctx, victim)
+		c.notify(ctx, notifier.Event{Type: notifier.EventDryRunTermination, Pod: &victim, DryRun: true})
 		return nil
 	}
 
+	// remember the owner's ready pods to tell when it has recovered from the termination.
+	readyBefore, trackRecovery := c.readyBeforeTermination(ctx, victim)
+
+	terminateCtx, terminateSpan := c.tracer().Start(ctx, "Terminate", trace.WithAttributes(
+		append(podAttributes(victim), attributeTerminator.String(terminator.Name(c.Terminator)))...,
+	))
 	start := time.Now()
-	err := c.Terminator.Terminate(ctx, victim)
+	err = c.Terminator.Terminate(terminateCtx, victim)
 	metrics.TerminationDurationSeconds.Observe(time.Since(start).Seconds())
+	endSpan(terminateSpan, err)
 	if err != nil {
+		metrics.TerminationsFailedTotal.WithLabelValues(append(labels, terminator.ErrorReason(err))...).Inc()
+		c.recordTermination(ctx, victim, err)
+		c.notify(ctx, notifier.Event{Type: notifier.EventTerminationFailed, Pod: &victim, Error: err})
 		return err
 	}
 
-	metrics.PodsDeletedTotal.WithLabelValues(victim.Namespace).Inc()
+	metrics.PodsDeletedTotal.WithLabelValues(append(labels, "false")...).Inc()
+
+	c.recordTermination(ctx, victim, nil)
+
+	c.notify(ctx, notifier.Event{Type: notifier.EventPodTerminated, Pod: &victim})
+
+	if trackRecovery {
+		c.watchRecovery(ctx, victim, readyBefore)
+	}
+
+	return nil
+}
+
+// notify sends the event to the notifier if it subscribes to the event's type.
+// Failed notifications are logged but don't fail the run.
+func (c *Chaoskube) notify(ctx context.Context, event notifier.Event) {
+	if !c.Notifier.Subscribes(event.Type) {
+		return
+	}
+	if event.Time.IsZero() {
+		event.Time = c.Now()
+	}
+	// the ID stays the same across the retries of each notifier
+	event.ID = string(uuid.NewUUID())
 
-	ref, err := reference.GetReference(scheme.Scheme, &victim)
+	ctx, span := c.tracer().Start(ctx, "Notify", trace.WithAttributes(attributeEvent.String(string(event.Type))))
+	if event.Pod != nil {
+		span.SetAttributes(podAttributes(*event.Pod)...)
+	}
+	err := c.Notifier.Notify(ctx, event)
+	endSpan(span, err)
 	if err != nil {
-		return err
+		c.Logger.WithFields(log.Fields{
+			"event": event.Type,
+			"err":   err,
+		}).Warn("failed to notify")
+	}
+}
+
+// IsDryRun returns whether dry-run mode is enabled.
+func (c *Chaoskube) IsDryRun() bool {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	return c.DryRun
+}
+
+// AnnotationArmed is the namespace annotation that enables real terminations of the
+// namespace's pods when set to "true", even though dry-run mode is enabled. It's only
+// honored if ArmedNamespaceAnnotation is set.
+const AnnotationArmed = "chaoskube.io/armed"
+
+// isDryRun returns whether the victim is only pretended to be terminated, i.e. whether
+// dry-run mode is enabled and the victim's namespace isn't armed. Once dry-run mode was
+// enabled at runtime, e.g. through the API, no namespace is armed anymore.
+func (c *Chaoskube) isDryRun(ctx context.Context, victim v1.Pod) bool {
+	c.mu.RLock()
+	dryRun, dryRunSet := c.DryRun, c.dryRunSet
+	c.mu.RUnlock()
+
+	if !dryRun {
+		return false
 	}
+	return dryRunSet || !c.isArmed(ctx, victim.Namespace)
+}
 
-	c.EventRecorder.Event(ref, v1.EventTypeNormal, "Killing", "Pod was terminated by chaoskube to introduce chaos.")
+// isArmed returns whether pods in the given namespace are terminated even in dry-run mode.
+// That's the case if the namespace matches the armed namespaces or namespace labels or,
+// if ArmedNamespaceAnnotation is set, if it's annotated with AnnotationArmed, which allows
+// teams to opt in one at a time.
+func (c *Chaoskube) isArmed(ctx context.Context, name string) bool {
+	if c.ArmedNamespaces != nil && !c.ArmedNamespaces.Empty() {
+		selector, err := util.NewNameSelector(c.ArmedNamespaces)
+		if err == nil && selector.Matches(name) {
+			return true
+		}
+	}
 
-	if err := c.Notifier.NotifyPodTermination(victim); err != nil {
-		c.Logger.WithField("err", err).Warn("failed to notify pod termination")
+	armedByLabels := c.ArmedNamespaceLabels != nil && !c.ArmedNamespaceLabels.Empty()
+	if !armedByLabels && !c.ArmedNamespaceAnnotation {
+		return false
 	}
 
-	return nil
+	namespace, err := c.NamespaceCache.Get(ctx, name)
+	if err != nil {
+		c.Logger.WithFields(log.Fields{
+			"namespace": name,
+			"err":       err,
+		}).Debug("failed to look up namespace, treating it as not armed")
+		return false
+	}
+
+	if c.ArmedNamespaceAnnotation && namespace.Annotations[AnnotationArmed] == "true" {
+		return true
+	}
+
+	return armedByLabels && c.ArmedNamespaceLabels.Matches(labels.Set(namespace.Labels))
+}
+
+// SetDryRun enables or disables dry-run mode at runtime. From then on, armed namespaces
+// are ignored, so that enabling dry-run mode stops all terminations.
+func (c *Chaoskube) SetDryRun(dryRun bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.DryRun = dryRun
+	c.dryRunSet = true
 }
 
 // filterByKinds filters a list of pods by a given kind selector.
@@ -346,48 +772,15 @@ func filterByNamespaces(pods []v1.Pod, namespaces labels.Selector) ([]v1.Pod, er
 		return pods, nil
 	}
 
-	// split requirements into including and excluding groups
-	reqs, _ := namespaces.Requirements()
-	reqIncl := []labels.Requirement{}
-	reqExcl := []labels.Requirement{}
-
-	for _, req := range reqs {
-		switch req.Operator() {
-		case selection.Exists:
-			reqIncl = append(reqIncl, req)
-		case selection.DoesNotExist:
-			reqExcl = append(reqExcl, req)
-		default:
-			return nil, fmt.Errorf("unsupported operator: %s", req.Operator())
-		}
+	selector, err := util.NewNameSelector(namespaces)
+	if err != nil {
+		return nil, err
 	}
 
 	filteredList := []v1.Pod{}
 
 	for _, pod := range pods {
-		// if there aren't any including requirements, we're in by default
-		included := len(reqIncl) == 0
-
-		// convert the pod's namespace to an equivalent label selector
-		selector := labels.Set{pod.Namespace: ""}
-
-		// include pod if one including requirement matches
-		for _, req := range reqIncl {
-			if req.Matches(selector) {
-				included = true
-				break
-			}
-		}
-
-		// exclude pod if it is filtered out by at least one excluding requirement
-		for _, req := range reqExcl {
-			if !req.Matches(selector) {
-				included = false
-				break
-			}
-		}
-
-		if included {
+		if selector.Matches(pod.Namespace) {
 			filteredList = append(filteredList, pod)
 		}
 	}
@@ -446,6 +839,24 @@ func filterByAnnotations(pods []v1.Pod, annotations labels.Selector) []v1.Pod {
 	return filteredList
 }
 
+// filterByLabels filters a list of pods by a given label selector.
+func filterByLabels(pods []v1.Pod, selector labels.Selector) []v1.Pod {
+	// empty filter returns original list
+	if selector.Empty() {
+		return pods
+	}
+
+	filteredList := []v1.Pod{}
+
+	for _, pod := range pods {
+		if selector.Matches(labels.Set(pod.Labels)) {
+			filteredList = append(filteredList, pod)
+		}
+	}
+
+	return filteredList
+}
+
 // filterByPhase filters a list of pods by a given PodPhase, e.g. Running.
 func filterByPhase(pods []v1.Pod, phase v1.PodPhase) []v1.Pod {
 	filteredList := []v1.Pod{}
@@ -513,6 +924,8 @@ func filterByPodName(pods []v1.Pod, includedPodNames, excludedPodNames *regexp.R
 	return filteredList
 }
 
+// filterByOwnerReference picks a single random pod of each owner. Pods
+// without an owner reference are always included.
 func filterByOwnerReference(pods []v1.Pod) []v1.Pod {
 	owners := make(map[types.UID][]v1.Pod)
 	filteredList := []v1.Pod{}
@@ -8,16 +8,27 @@ import (
 	"testing"
 	"time"
 
+	promtest "github.com/prometheus/client_golang/prometheus/testutil"
 	log "github.com/sirupsen/logrus"
 	"github.com/sirupsen/logrus/hooks/test"
+	"go.opentelemetry.io/otel/attribute"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
 
+	appsv1 "k8s.io/api/apps/v1"
 	v1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/types"
 	"k8s.io/client-go/kubernetes"
 	"k8s.io/client-go/kubernetes/fake"
+	ktesting "k8s.io/client-go/testing"
+	"k8s.io/client-go/tools/record"
 
+	"github.com/linki/chaoskube/audit"
+	"github.com/linki/chaoskube/health"
 	"github.com/linki/chaoskube/internal/testutil"
+	"github.com/linki/chaoskube/metrics"
 	"github.com/linki/chaoskube/notifier"
 	"github.com/linki/chaoskube/terminator"
 	"github.com/linki/chaoskube/util"
@@ -107,6 +118,111 @@ func (suite *Suite) TestNew() {
 	suite.Equal(terminator, chaoskube.Terminator)
 }
 
+// TestNewWithOptions tests that options are applied to the new instance and defaults fill the gaps
+func (suite *Suite) TestNewWithOptions() {
+	var (
+		client           = fake.NewSimpleClientset()
+		labelSelector, _ = labels.Parse("foo=bar")
+		excludedWeekdays = []time.Weekday{time.Friday}
+		now              = func() time.Time { return time.Date(1869, 9, 24, 15, 4, 5, 0, time.UTC) }
+		recorder         = record.NewFakeRecorder(1)
+		checker          = staticChecker{}
+		filter           = TerminatingFilter{}
+	)
+
+	chaoskube, err := NewWithOptions(client,
+		WithLabels(labelSelector),
+		WithQuietTimes(excludedWeekdays, nil, nil),
+		WithMaxKill(3),
+		WithDryRun(true),
+		WithLogger(logger),
+		WithNotifier(testNotifier),
+		WithEventRecorder(recorder),
+		WithClock(now),
+		WithHealthCheckers(checker),
+		WithFilters(filter),
+	)
+	suite.Require().NoError(err)
+
+	suite.Equal(client, chaoskube.Client)
+	suite.Equal("foo=bar", chaoskube.Labels.String())
+	suite.True(chaoskube.Annotations.Empty())
+	suite.True(chaoskube.Namespaces.Empty())
+	suite.Equal(excludedWeekdays, chaoskube.ExcludedWeekdays)
+	suite.Equal(time.UTC, chaoskube.Timezone)
+	suite.Equal(3, chaoskube.MaxKill)
+	suite.True(chaoskube.DryRun)
+	suite.Equal(v1.NamespaceAll, chaoskube.ClientNamespaceScope)
+	suite.Equal(logger, chaoskube.Logger)
+	suite.Equal(testNotifier, chaoskube.Notifier)
+	suite.Equal(recorder, chaoskube.EventRecorder)
+	suite.Equal(now(), chaoskube.Now())
+	suite.Equal([]health.Checker{checker}, chaoskube.HealthCheckers)
+	suite.Equal([]Filter{filter}, chaoskube.Filters)
+	suite.IsType(&terminator.DeletePodTerminator{}, chaoskube.Terminator)
+	suite.Require().NotNil(chaoskube.PauseSwitch)
+	suite.Equal(now(), chaoskube.PauseSwitch.Now())
+
+	_, err = NewWithOptions(client, WithMaxKill(0))
+	suite.EqualError(err, "max kill must be at least 1, got 0")
+
+	_, err = NewWithOptions(nil)
+	suite.EqualError(err, "client must not be nil")
+}
+
+// TestConfigValidate tests that invalid configurations are rejected
+func (suite *Suite) TestConfigValidate() {
+	for _, tt := range []struct {
+		modify func(*Config)
+		err    string
+	}{
+		{func(c *Config) {}, ""},
+		{func(c *Config) { c.Labels = nil }, "label selector must not be nil"},
+		{func(c *Config) { c.NamespaceLabels = nil }, "namespace label selector must not be nil"},
+		{func(c *Config) { c.Kinds, _ = labels.Parse("kind=job") }, "invalid kind selector: unsupported operator: ="},
+		{func(c *Config) { c.Namespaces, _ = labels.Parse("foo in (bar)") }, "invalid namespace selector: unsupported operator: in"},
+		{func(c *Config) { c.ArmedNamespaces, _ = labels.Parse("team=search") }, "invalid armed namespace selector: unsupported operator: ="},
+		{func(c *Config) { c.Timezone = nil }, "timezone must not be nil"},
+		{func(c *Config) { c.MinimumAge = -time.Minute }, "minimum age must not be negative, got -1m0s"},
+		{func(c *Config) { c.MaxKill = 0 }, "max kill must be at least 1, got 0"},
+		{func(c *Config) { c.FilterNames = []string{"phase", "bogus"} }, `invalid filters: unknown filter "bogus", expected one of namespaces, namespaceLabels, kinds, annotations, phase, terminating, minimumAge, podName, ownerReference`},
+		{func(c *Config) { c.FilterNames = []string{"phase", "phase"} }, `invalid filters: filter "phase" is given more than once`},
+	} {
+		config := DefaultConfig()
+		tt.modify(&config)
+
+		if tt.err == "" {
+			suite.NoError(config.Validate())
+		} else {
+			suite.EqualError(config.Validate(), tt.err)
+		}
+	}
+}
+
+// TestFilterNames tests that the built-in filters can be reordered and disabled.
+func (suite *Suite) TestFilterNames() {
+	client := fake.NewSimpleClientset()
+
+	chaoskube, err := NewWithOptions(client, WithFilterNames("podName", "phase"))
+	suite.Require().NoError(err)
+
+	names := []string{}
+	for _, filter := range chaoskube.filters() {
+		names = append(names, filter.Name())
+	}
+	suite.Equal([]string{"optOut", "podName", "phase"}, names)
+
+	// the opt-out filter can't be disabled
+	pod := util.NewPod("default", "foo", v1.PodRunning)
+	pod.Annotations = map[string]string{AnnotationOptOut: "true"}
+	_, err = client.CoreV1().Pods("default").Create(context.Background(), &pod, metav1.CreateOptions{})
+	suite.Require().NoError(err)
+
+	candidates, err := chaoskube.Candidates(context.Background())
+	suite.Require().NoError(err)
+	suite.Empty(candidates)
+}
+
 // TestRunContextCanceled tests that a canceled context will exit the Run function.
 func (suite *Suite) TestRunContextCanceled() {
 	chaoskube := suite.setup(
@@ -312,6 +428,325 @@ func (suite *Suite) TestCandidatesPodNameRegexp() {
 	}
 }
 
+// TestExplain tests that each pod is reported along with the filter that rejected it.
+func (suite *Suite) TestExplain() {
+	for _, tt := range []struct {
+		labelSelector     string
+		namespaceSelector string
+		explanations      []Explanation
+	}{
+		{"", "", []Explanation{
+			{Namespace: "default", Name: "foo", Candidate: true},
+			{Namespace: "testing", Name: "bar", Candidate: true},
+			{Namespace: "testing", Name: "baz", Filter: "phase", Reason: "pod isn't in phase Running"},
+		}},
+		{"app!=bar", "!default", []Explanation{
+			{Namespace: "default", Name: "foo", Filter: "namespaces", Reason: `namespace doesn't match selector "!default"`},
+			{Namespace: "testing", Name: "bar", Filter: "labels", Reason: `labels don't match selector "app!=bar"`},
+			{Namespace: "testing", Name: "baz", Filter: "phase", Reason: "pod isn't in phase Running"},
+		}},
+	} {
+		labelSelector, err := labels.Parse(tt.labelSelector)
+		suite.Require().NoError(err)
+
+		namespaceSelector, err := labels.Parse(tt.namespaceSelector)
+		suite.Require().NoError(err)
+
+		chaoskube := suite.setupWithPods(
+			labelSelector,
+			labels.Everything(),
+			labels.Everything(),
+			namespaceSelector,
+			labels.Everything(),
+			nil,
+			nil,
+			[]time.Weekday{},
+			[]util.TimePeriod{},
+			[]time.Time{},
+			time.UTC,
+			time.Duration(0),
+			false,
+			10,
+			v1.NamespaceAll,
+		)
+
+		explanations, err := chaoskube.Explain(context.Background())
+		suite.Require().NoError(err)
+		suite.Equal(tt.explanations, explanations)
+	}
+}
+
+// TestCustomFilters tests that a configured filter pipeline replaces the default one.
+func (suite *Suite) TestCustomFilters() {
+	chaoskube := suite.setupWithPods(
+		labels.Everything(),
+		labels.Everything(),
+		labels.Everything(),
+		labels.Everything(),
+		labels.Everything(),
+		nil,
+		nil,
+		[]time.Weekday{},
+		[]util.TimePeriod{},
+		[]time.Time{},
+		time.UTC,
+		time.Duration(0),
+		false,
+		10,
+		v1.NamespaceAll,
+	)
+
+	catalog := NewFilter("catalog", func(_ context.Context, pods []v1.Pod) ([]v1.Pod, string, error) {
+		filtered := []v1.Pod{}
+		for _, pod := range pods {
+			if pod.Name != "foo" {
+				filtered = append(filtered, pod)
+			}
+		}
+		return filtered, "pod is critical", nil
+	})
+	chaoskube.Filters = []Filter{catalog}
+
+	// the pending pod is a candidate as well as the phase filter isn't part of the pipeline
+	suite.assertCandidates(chaoskube, []map[string]string{
+		{"namespace": "testing", "name": "bar"},
+		{"namespace": "testing", "name": "baz"},
+	})
+
+	explanations, err := chaoskube.Explain(context.Background())
+	suite.Require().NoError(err)
+	suite.Equal(Explanation{Namespace: "default", Name: "foo", Filter: "catalog", Reason: "pod is critical"}, explanations[0])
+
+	chaoskube.Filters = append(DefaultFilters(chaoskube), catalog)
+
+	suite.assertCandidates(chaoskube, []map[string]string{
+		{"namespace": "testing", "name": "bar"},
+	})
+}
+
+// TestMetrics tests that a run exports the candidates per filter stage, the victims and skipped intervals.
+func (suite *Suite) TestMetrics() {
+	chaoskube := suite.setupWithPods(
+		labels.Everything(),
+		labels.Everything(),
+		labels.Everything(),
+		labels.Everything(),
+		labels.Everything(),
+		nil,
+		nil,
+		[]time.Weekday{},
+		[]util.TimePeriod{},
+		[]time.Time{},
+		time.UTC,
+		time.Duration(0),
+		true,
+		10,
+		v1.NamespaceAll,
+	)
+
+	suite.Require().NoError(chaoskube.TerminateVictims(context.Background()))
+
+	suite.Equal(3.0, promtest.ToFloat64(metrics.Candidates.WithLabelValues("labels")))
+	suite.Equal(3.0, promtest.ToFloat64(metrics.Candidates.WithLabelValues("namespaces")))
+	suite.Equal(2.0, promtest.ToFloat64(metrics.Candidates.WithLabelValues("phase")))
+	suite.Equal(2.0, promtest.ToFloat64(metrics.Candidates.WithLabelValues("ownerReference")))
+	suite.Equal(1.0, promtest.ToFloat64(metrics.Victims))
+
+	// previews don't overwrite the values of the last run
+	chaoskube.Namespaces, _ = labels.Parse("unknown")
+	_, err := chaoskube.Victims(context.Background())
+	suite.Equal(errPodNotFound, err)
+	suite.Equal(3.0, promtest.ToFloat64(metrics.Candidates.WithLabelValues("namespaces")))
+	suite.Equal(1.0, promtest.ToFloat64(metrics.Victims))
+
+	skipped := promtest.ToFloat64(metrics.IntervalsSkippedTotal.WithLabelValues(metrics.SkipReasonNoVictim))
+
+	suite.Require().NoError(chaoskube.TerminateVictims(context.Background()))
+
+	suite.Equal(0.0, promtest.ToFloat64(metrics.Candidates.WithLabelValues("namespaces")))
+	suite.Equal(0.0, promtest.ToFloat64(metrics.Victims))
+	suite.Equal(skipped+1, promtest.ToFloat64(metrics.IntervalsSkippedTotal.WithLabelValues(metrics.SkipReasonNoVictim)))
+}
+
+// TestTerminationMetrics tests that terminations are counted with the configured labels.
+func (suite *Suite) TestTerminationMetrics() {
+	chaoskube := suite.setupWithPods(
+		labels.Everything(),
+		labels.Everything(),
+		labels.Everything(),
+		labels.Everything(),
+		labels.Everything(),
+		nil,
+		nil,
+		[]time.Weekday{},
+		[]util.TimePeriod{},
+		[]time.Time{},
+		time.UTC,
+		time.Duration(0),
+		false,
+		10,
+		v1.NamespaceAll,
+	)
+
+	metricLabels, err := metrics.NewTerminationLabels([]string{metrics.LabelNode, metrics.LabelTerminator}, 0)
+	suite.Require().NoError(err)
+	chaoskube.MetricLabels = metricLabels
+
+	pod := util.NewPod("default", "foo", v1.PodRunning)
+	pod.Spec.NodeName = "node-1"
+
+	deleted := metrics.PodsDeletedTotal.WithLabelValues("default", "", "", "node-1", "DeletePod", "false")
+	before := promtest.ToFloat64(deleted)
+	suite.Require().NoError(chaoskube.DeletePod(context.Background(), pod))
+	suite.Equal(before+1, promtest.ToFloat64(deleted))
+
+	// the pod is gone now, so terminating it again fails
+	failed := metrics.TerminationsFailedTotal.WithLabelValues("default", "", "", "node-1", "DeletePod", terminator.ReasonNotFound)
+	before = promtest.ToFloat64(failed)
+	suite.Error(chaoskube.DeletePod(context.Background(), pod))
+	suite.Equal(before+1, promtest.ToFloat64(failed))
+}
+
+// TestTracing tests that a run records spans for listing, filtering, selecting, terminating and notifying.
+func (suite *Suite) TestTracing() {
+	chaoskube := suite.setupWithPods(
+		labels.Everything(),
+		labels.Everything(),
+		labels.Everything(),
+		labels.Everything(),
+		labels.Everything(),
+		regexp.MustCompile("foo"),
+		nil,
+		[]time.Weekday{},
+		[]util.TimePeriod{},
+		[]time.Time{},
+		time.UTC,
+		time.Duration(0),
+		false,
+		10,
+		v1.NamespaceAll,
+	)
+
+	recorder := tracetest.NewSpanRecorder()
+	chaoskube.Tracer = sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(recorder)).Tracer("test")
+
+	suite.Require().NoError(chaoskube.TerminateVictims(context.Background()))
+
+	spans := map[string]sdktrace.ReadOnlySpan{}
+	for _, span := range recorder.Ended() {
+		spans[span.Name()] = span
+	}
+
+	for _, name := range []string{"ListPods", "Filter namespaces", "Filter podName", "SelectVictims", "DeletePod", "Terminate", "Notify"} {
+		suite.Require().Contains(spans, name)
+		suite.Equal(spans["TerminateVictims"].SpanContext().TraceID(), spans[name].SpanContext().TraceID())
+	}
+
+	suite.Contains(spans["Filter podName"].Attributes(), attribute.Int("chaoskube.pods.out", 1))
+	suite.Contains(spans["Terminate"].Attributes(), attribute.String("k8s.namespace.name", "default"))
+	suite.Contains(spans["Terminate"].Attributes(), attribute.String("k8s.pod.name", "foo"))
+	suite.Contains(spans["Terminate"].Attributes(), attribute.String("chaoskube.terminator", "DeletePod"))
+	suite.Equal(spans["DeletePod"].SpanContext().SpanID(), spans["Terminate"].Parent().SpanID())
+
+	// without a tracer the global one is used
+	chaoskube.Tracer = nil
+	suite.NoError(chaoskube.TerminateVictims(context.Background()))
+}
+
+// TestAudit tests that each run writes an audit record describing its decision.
+func (suite *Suite) TestAudit() {
+	chaoskube := suite.setupWithPods(
+		labels.Everything(),
+		labels.Everything(),
+		labels.Everything(),
+		labels.Everything(),
+		labels.Everything(),
+		nil,
+		nil,
+		[]time.Weekday{time.Saturday},
+		[]util.TimePeriod{},
+		[]time.Time{},
+		time.UTC,
+		time.Duration(0),
+		true,
+		10,
+		v1.NamespaceAll,
+	)
+
+	sink := &recordingSink{}
+	chaoskube.AuditSink = sink
+	chaoskube.Now = ThankGodItsFriday{}.Now
+
+	suite.Require().NoError(chaoskube.TerminateVictims(context.Background()))
+
+	suite.Require().Len(sink.records, 1)
+	record := sink.records[0]
+	suite.Equal(ThankGodItsFriday{}.Now(), record.Time)
+	suite.Equal(chaoskube.ConfigHash(), record.ConfigHash)
+	suite.Len(record.ConfigHash, 16)
+	suite.Equal(2, record.Candidates)
+	suite.Len(record.Victims, 1)
+	suite.Equal("DeletePod", record.Terminator)
+	suite.True(record.DryRun)
+	suite.Equal(audit.OutcomeTerminated, record.Outcome)
+	suite.Empty(record.Errors)
+
+	chaoskube.Now = func() time.Time { return ThankGodItsFriday{}.Now().Add(24 * time.Hour) }
+	suite.Require().NoError(chaoskube.TerminateVictims(context.Background()))
+
+	suite.Require().Len(sink.records, 2)
+	suite.Equal(audit.OutcomeSkipped, sink.records[1].Outcome)
+	suite.Equal(metrics.SkipReasonWeekday, sink.records[1].SkipReason)
+
+	// a different configuration results in a different hash
+	chaoskube.MaxKill = 2
+	suite.NotEqual(record.ConfigHash, chaoskube.ConfigHash())
+}
+
+// recordingSink is an audit sink that keeps all records in memory.
+type recordingSink struct {
+	records []audit.Record
+}
+
+func (s *recordingSink) Write(_ context.Context, record audit.Record) error {
+	s.records = append(s.records, record)
+	return nil
+}
+
+// TestQuietTime tests that the active quiet time is reported.
+func (suite *Suite) TestQuietTime() {
+	chaoskube := suite.setup(
+		labels.Everything(),
+		labels.Everything(),
+		labels.Everything(),
+		labels.Everything(),
+		labels.Everything(),
+		nil,
+		nil,
+		[]time.Weekday{time.Saturday},
+		[]util.TimePeriod{},
+		[]time.Time{time.Date(0, 12, 24, 0, 0, 0, 0, time.UTC)},
+		time.UTC,
+		time.Duration(0),
+		true,
+		10,
+		1,
+		v1.NamespaceAll,
+	)
+
+	for _, tt := range []struct {
+		now    time.Time
+		reason string
+	}{
+		{time.Date(2026, 10, 16, 12, 0, 0, 0, time.UTC), ""},
+		{time.Date(2026, 10, 17, 12, 0, 0, 0, time.UTC), metrics.SkipReasonWeekday},
+		{time.Date(2026, 12, 24, 12, 0, 0, 0, time.UTC), metrics.SkipReasonDayOfYear},
+	} {
+		chaoskube.Now = func() time.Time { return tt.now }
+		suite.Equal(tt.reason, chaoskube.QuietTime())
+	}
+}
+
 // TestVictim tests that a random victim is chosen from selected candidates.
 func (suite *Suite) TestVictim() {
 	foo := map[string]string{"namespace": "default", "name": "foo"}
@@ -766,6 +1201,351 @@ func (suite *Suite) TestTerminateNoVictimLogsInfo() {
 	suite.AssertLog(logOutput, log.DebugLevel, msgVictimNotFound, log.Fields{})
 }
 
+// TestTerminateVictimsHealthCheck tests that failing health checks suspend termination.
+func (suite *Suite) TestTerminateVictimsHealthCheck() {
+	for _, tt := range []struct {
+		checkers          []health.Checker
+		remainingPodCount int
+	}{
+		{[]health.Checker{}, 1},
+		{[]health.Checker{staticChecker{}}, 1},
+		{[]health.Checker{staticChecker{}, staticChecker{&health.Result{Check: "test", Message: "on fire"}}}, 2},
+	} {
+		chaoskube := suite.setupWithPods(
+			labels.Everything(),
+			labels.Everything(),
+			labels.Everything(),
+			labels.Everything(),
+			labels.Everything(),
+			&regexp.Regexp{},
+			&regexp.Regexp{},
+			[]time.Weekday{},
+			[]util.TimePeriod{},
+			[]time.Time{},
+			time.UTC,
+			time.Duration(0),
+			false,
+			10,
+			v1.NamespaceAll,
+		)
+		chaoskube.HealthCheckers = tt.checkers
+
+		err := chaoskube.TerminateVictims(context.Background())
+		suite.Require().NoError(err)
+
+		pods, err := chaoskube.Candidates(context.Background())
+		suite.Require().NoError(err)
+
+		suite.Len(pods, tt.remainingPodCount)
+	}
+
+	suite.AssertLog(logOutput, log.DebugLevel, msgHealthCheckFailed, log.Fields{"check": "test", "reason": "on fire"})
+}
+
+// TestTerminateVictimsPaused tests that no pods are terminated while chaoskube is paused.
+func (suite *Suite) TestTerminateVictimsPaused() {
+	chaoskube := suite.setupWithPods(
+		labels.Everything(),
+		labels.Everything(),
+		labels.Everything(),
+		labels.Everything(),
+		labels.Everything(),
+		&regexp.Regexp{},
+		&regexp.Regexp{},
+		[]time.Weekday{},
+		[]util.TimePeriod{},
+		[]time.Time{},
+		time.UTC,
+		time.Duration(0),
+		false,
+		10,
+		v1.NamespaceAll,
+	)
+
+	chaoskube.PauseSwitch.Pause("test", time.Time{})
+
+	err := chaoskube.TerminateVictims(context.Background())
+	suite.Require().NoError(err)
+
+	suite.AssertLog(logOutput, log.DebugLevel, msgPaused, log.Fields{"sources": []string{"test"}})
+
+	pods, err := chaoskube.Candidates(context.Background())
+	suite.Require().NoError(err)
+	suite.Len(pods, 2)
+
+	chaoskube.PauseSwitch.Resume("test")
+
+	err = chaoskube.TerminateVictims(context.Background())
+	suite.Require().NoError(err)
+
+	pods, err = chaoskube.Candidates(context.Background())
+	suite.Require().NoError(err)
+	suite.Len(pods, 1)
+}
+
+// TestTerminateVictimsHealthCheckEvent tests that failing health checks emit an event on the offending object.
+func (suite *Suite) TestTerminateVictimsHealthCheckEvent() {
+	chaoskube := suite.setupWithPods(
+		labels.Everything(),
+		labels.Everything(),
+		labels.Everything(),
+		labels.Everything(),
+		labels.Everything(),
+		&regexp.Regexp{},
+		&regexp.Regexp{},
+		[]time.Weekday{},
+		[]util.TimePeriod{},
+		[]time.Time{},
+		time.UTC,
+		time.Duration(0),
+		false,
+		10,
+		v1.NamespaceAll,
+	)
+	recorder := record.NewFakeRecorder(1)
+	chaoskube.EventRecorder = recorder
+
+	node := &v1.Node{ObjectMeta: metav1.ObjectMeta{Name: "node"}}
+	chaoskube.HealthCheckers = []health.Checker{
+		staticChecker{&health.Result{Check: health.CheckNotReadyNodes, Message: "too many nodes are not ready", Object: node}},
+	}
+
+	err := chaoskube.TerminateVictims(context.Background())
+	suite.Require().NoError(err)
+
+	suite.Equal("Warning ChaosSkipped Chaos was skipped by chaoskube: too many nodes are not ready", <-recorder.Events)
+}
+
+// TestEvents tests that terminations are recorded on the pod, its owner and its namespace
+// and that skipped intervals are recorded on chaoskube's own pod.
+func (suite *Suite) TestEvents() {
+	client := fake.NewSimpleClientset()
+	recorder := record.NewFakeRecorder(10)
+	recorder.IncludeObject = true
+
+	chaoskube, err := NewWithOptions(client,
+		WithLogger(logger),
+		WithNotifier(testNotifier),
+		WithEventRecorder(recorder),
+		WithTerminator(terminator.NewDeletePodTerminator(client, logger, 30*time.Second)),
+		WithExperiment("game-day"),
+		WithSelf(&v1.ObjectReference{APIVersion: "v1", Kind: "Pod", Namespace: "chaoskube", Name: "chaoskube-1"}),
+	)
+	suite.Require().NoError(err)
+
+	isController := true
+	replicaSet := &appsv1.ReplicaSet{ObjectMeta: metav1.ObjectMeta{
+		Namespace: "default",
+		Name:      "foo-5d8f",
+		OwnerReferences: []metav1.OwnerReference{
+			{APIVersion: "apps/v1", Kind: "Deployment", Name: "foo", UID: "deployment", Controller: &isController},
+		},
+	}}
+	_, err = client.AppsV1().ReplicaSets("default").Create(context.Background(), replicaSet, metav1.CreateOptions{})
+	suite.Require().NoError(err)
+
+	pod := util.NewPodWithOwner("default", "foo-5d8f-x2k4", v1.PodRunning, "replicaset")
+	pod.OwnerReferences[0].APIVersion = "apps/v1"
+	pod.OwnerReferences[0].Kind = "ReplicaSet"
+	pod.OwnerReferences[0].Name = "foo-5d8f"
+	_, err = client.CoreV1().Pods("default").Create(context.Background(), &pod, metav1.CreateOptions{})
+	suite.Require().NoError(err)
+
+	details := "terminator: DeletePod, grace period: 30s, experiment: game-day"
+
+	// a successful termination
+	suite.Require().NoError(chaoskube.DeletePod(context.Background(), pod))
+	suite.Equal("Normal Killing Pod was terminated by chaoskube to introduce chaos ("+details+"). involvedObject{kind=Pod,apiVersion=v1}", <-recorder.Events)
+	suite.Equal("Normal ChaosTerminated Pod foo-5d8f-x2k4 was terminated by chaoskube to introduce chaos ("+details+"). involvedObject{kind=Deployment,apiVersion=apps/v1}", <-recorder.Events)
+	suite.Equal("Normal ChaosTerminated Pod foo-5d8f-x2k4 was terminated by chaoskube to introduce chaos ("+details+"). involvedObject{kind=Namespace,apiVersion=v1}", <-recorder.Events)
+
+	// the pod is gone now, so terminating it again fails
+	suite.Require().Error(chaoskube.DeletePod(context.Background(), pod))
+	suite.Contains(<-recorder.Events, "Warning ChaosFailed Pod could not be terminated by chaoskube: pods \"foo-5d8f-x2k4\" not found ("+details+"). involvedObject{kind=Pod")
+	suite.Contains(<-recorder.Events, "Warning ChaosFailed Pod foo-5d8f-x2k4 could not be terminated by chaoskube: pods \"foo-5d8f-x2k4\" not found ("+details+"). involvedObject{kind=Deployment")
+	suite.Contains(<-recorder.Events, "Warning ChaosFailed Pod foo-5d8f-x2k4 could not be terminated by chaoskube: pods \"foo-5d8f-x2k4\" not found ("+details+"). involvedObject{kind=Namespace")
+
+	// without candidates the interval is skipped
+	suite.Require().NoError(chaoskube.TerminateVictims(context.Background()))
+	suite.Equal("Normal ChaosSkipped Chaos was skipped by chaoskube: no_victim involvedObject{kind=Pod,apiVersion=v1}", <-recorder.Events)
+
+	// failed health checks are warnings
+	node := &v1.Node{ObjectMeta: metav1.ObjectMeta{Name: "node"}}
+	chaoskube.HealthCheckers = []health.Checker{
+		staticChecker{&health.Result{Check: health.CheckNotReadyNodes, Message: "too many nodes are not ready", Object: node}},
+	}
+	suite.Require().NoError(chaoskube.TerminateVictims(context.Background()))
+	suite.Contains(<-recorder.Events, "Warning ChaosSkipped Chaos was skipped by chaoskube: too many nodes are not ready")
+	suite.Equal("Warning ChaosSkipped Chaos was skipped by chaoskube: health_check involvedObject{kind=Pod,apiVersion=v1}", <-recorder.Events)
+	suite.Empty(recorder.Events)
+}
+
+// TestDryRunPipeline tests that dry-run terminations are counted, recorded and notified like real ones.
+func (suite *Suite) TestDryRunPipeline() {
+	client := fake.NewSimpleClientset()
+	recorder := record.NewFakeRecorder(10)
+	events := &notifier.Noop{}
+
+	chaoskube, err := NewWithOptions(client,
+		WithLogger(logger),
+		WithNotifier(events),
+		WithEventRecorder(recorder),
+		WithDryRun(true),
+	)
+	suite.Require().NoError(err)
+
+	pod := util.NewPod("default", "foo", v1.PodRunning)
+	_, err = client.CoreV1().Pods("default").Create(context.Background(), &pod, metav1.CreateOptions{})
+	suite.Require().NoError(err)
+
+	dryRun := metrics.PodsDeletedTotal.WithLabelValues("default", "", "", "", "", "true")
+	deleted := metrics.PodsDeletedTotal.WithLabelValues("default", "", "", "", "", "false")
+	before, deletedBefore := promtest.ToFloat64(dryRun), promtest.ToFloat64(deleted)
+
+	suite.Require().NoError(chaoskube.DeletePod(context.Background(), pod))
+
+	// dry-run terminations are told apart from real ones by their label
+	suite.Equal(before+1, promtest.ToFloat64(dryRun))
+	suite.Equal(deletedBefore, promtest.ToFloat64(deleted))
+	suite.Equal("Normal ChaosDryRun Pod would have been terminated by chaoskube but dry-run mode is enabled (terminator: DeletePod, grace period: pod's default).", <-recorder.Events)
+	suite.Equal("Normal ChaosDryRun Pod foo would have been terminated by chaoskube but dry-run mode is enabled (terminator: DeletePod, grace period: pod's default).", <-recorder.Events)
+	suite.Empty(recorder.Events)
+
+	suite.Equal([]notifier.EventType{notifier.EventDryRunTermination}, events.Received())
+	suite.True(events.Events[0].DryRun)
+
+	// the pod is still there
+	_, err = client.CoreV1().Pods("default").Get(context.Background(), "foo", metav1.GetOptions{})
+	suite.NoError(err)
+}
+
+// TestArmedNamespaces tests that pods in armed namespaces are terminated even in dry-run mode.
+func (suite *Suite) TestArmedNamespaces() {
+	client := fake.NewSimpleClientset(
+		&v1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: "default"}},
+		&v1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: "payments", Annotations: map[string]string{AnnotationArmed: "true"}}},
+		&v1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: "search", Labels: map[string]string{"team": "search"}}},
+	)
+	events := &notifier.Noop{}
+	sink := &recordingSink{}
+	armed, _ := labels.Parse("checkout")
+
+	chaoskube, err := NewWithOptions(client,
+		WithLogger(logger),
+		WithNotifier(events),
+		WithEventRecorder(record.NewFakeRecorder(10)),
+		WithAuditSink(sink),
+		WithDryRun(true),
+		WithMaxKill(4),
+		WithArmedNamespaces(armed),
+		WithArmedNamespaceLabels(labels.SelectorFromSet(labels.Set{"team": "search"})),
+		WithArmedNamespaceAnnotation(true),
+	)
+	suite.Require().NoError(err)
+
+	for _, namespace := range []string{"default", "payments", "search", "checkout"} {
+		pod := util.NewPod(namespace, "foo", v1.PodRunning)
+		_, err = client.CoreV1().Pods(namespace).Create(context.Background(), &pod, metav1.CreateOptions{})
+		suite.Require().NoError(err)
+	}
+
+	suite.Require().NoError(chaoskube.TerminateVictims(context.Background()))
+
+	for _, tt := range []struct {
+		namespace string
+		armed     bool
+	}{
+		{"default", false},
+		{"payments", true},
+		{"search", true},
+		{"checkout", true},
+	} {
+		_, err := client.CoreV1().Pods(tt.namespace).Get(context.Background(), "foo", metav1.GetOptions{})
+		suite.Equal(tt.armed, err != nil, tt.namespace)
+	}
+
+	suite.ElementsMatch([]notifier.EventType{
+		notifier.EventIntervalStarted,
+		notifier.EventVictimsSelected,
+		notifier.EventDryRunTermination,
+		notifier.EventPodTerminated,
+		notifier.EventPodTerminated,
+		notifier.EventPodTerminated,
+		notifier.EventIntervalCompleted,
+	}, events.Received())
+
+	completed := events.Events[len(events.Events)-1]
+	suite.True(completed.DryRun)
+	for _, result := range completed.Results {
+		suite.Equal(result.Pod.Namespace != "default", result.Armed, result.Pod.Namespace)
+	}
+
+	suite.Require().Len(sink.records, 1)
+	suite.True(sink.records[0].DryRun)
+	for _, victim := range sink.records[0].Victims {
+		suite.Equal(victim.Namespace != "default", victim.Armed, victim.Namespace)
+	}
+
+	// without dry-run mode every namespace is armed
+	chaoskube.SetDryRun(false)
+	pod := util.NewPod("default", "foo", v1.PodRunning)
+	suite.Require().NoError(chaoskube.DeletePod(context.Background(), pod))
+	_, err = client.CoreV1().Pods("default").Get(context.Background(), "foo", metav1.GetOptions{})
+	suite.Error(err)
+
+	// enabling dry-run mode at runtime disarms all namespaces
+	chaoskube.SetDryRun(true)
+	for _, namespace := range []string{"payments", "search", "checkout"} {
+		suite.True(chaoskube.isDryRun(context.Background(), util.NewPod(namespace, "foo", v1.PodRunning)), namespace)
+	}
+
+	// the annotation is ignored unless it's enabled
+	chaoskube, err = NewWithOptions(client, WithLogger(logger), WithDryRun(true))
+	suite.Require().NoError(err)
+	suite.True(chaoskube.isDryRun(context.Background(), util.NewPod("payments", "foo", v1.PodRunning)))
+}
+
+// TestOwnerReference tests that pods are attributed to their top-level workload.
+func (suite *Suite) TestOwnerReference() {
+	chaoskube := suite.setup(
+		labels.Everything(),
+		labels.Everything(),
+		labels.Everything(),
+		labels.Everything(),
+		labels.Everything(),
+		&regexp.Regexp{},
+		&regexp.Regexp{},
+		[]time.Weekday{},
+		[]util.TimePeriod{},
+		[]time.Time{},
+		time.UTC,
+		time.Duration(0),
+		false,
+		10,
+		1,
+		v1.NamespaceAll,
+	)
+
+	withOwner := func(kind, name string) v1.Pod {
+		pod := util.NewPodWithOwner("default", "foo", v1.PodRunning, "owner")
+		pod.OwnerReferences[0].Kind = kind
+		pod.OwnerReferences[0].Name = name
+		return pod
+	}
+
+	for _, tt := range []struct {
+		name     string
+		pod      v1.Pod
+		expected *v1.ObjectReference
+	}{
+		{"no owner", util.NewPod("default", "foo", v1.PodRunning), nil},
+		{"job", withOwner("Job", "backup"), &v1.ObjectReference{Kind: "Job", Namespace: "default", Name: "backup", UID: "owner"}},
+		{"unknown replicaset", withOwner("ReplicaSet", "foo-5d8f"), &v1.ObjectReference{Kind: "ReplicaSet", Namespace: "default", Name: "foo-5d8f", UID: "owner"}},
+	} {
+		suite.Equal(tt.expected, chaoskube.ownerReference(context.Background(), tt.pod), tt.name)
+	}
+}
+
 // helper functions
 
 func (suite *Suite) assertCandidates(chaoskube *Chaoskube, expected []map[string]string) {
@@ -878,6 +1658,16 @@ func TestSuite(t *testing.T) {
 	suite.Run(t, new(Suite))
 }
 
+// staticChecker is a health checker that always returns the same result.
+type staticChecker struct {
+	result *health.Result
+}
+
+// Check returns the static result.
+func (c staticChecker) Check(ctx context.Context) (*health.Result, error) {
+	return c.result, nil
+}
+
 // ThankGodItsFriday is a helper struct that contains a Now() function that always returns a Friday.
 type ThankGodItsFriday struct{}
 
@@ -1168,3 +1958,300 @@ func (suite *Suite) TestNotifierCall() {
 	suite.Require().NoError(err)
 	suite.assertNotified(testNotifier)
 }
+
+// TestNotifierLifecycle tests that each stage of an interval is reported to the notifier.
+func (suite *Suite) TestNotifierLifecycle() {
+	chaoskube := suite.setupWithPods(
+		labels.Everything(),
+		labels.Everything(),
+		labels.Everything(),
+		labels.Everything(),
+		labels.Everything(),
+		regexp.MustCompile("foo"),
+		nil,
+		[]time.Weekday{time.Saturday},
+		[]util.TimePeriod{},
+		[]time.Time{},
+		time.UTC,
+		time.Duration(0),
+		false,
+		10,
+		v1.NamespaceAll,
+	)
+
+	var events *notifier.Noop
+	reset := func() {
+		events = &notifier.Noop{}
+		chaoskube.Notifier = events
+	}
+
+	// a skipped interval
+	reset()
+	chaoskube.Now = func() time.Time { return time.Date(2026, 10, 17, 12, 0, 0, 0, time.UTC) }
+	suite.Require().NoError(chaoskube.TerminateVictims(context.Background()))
+	suite.Equal([]notifier.EventType{notifier.EventIntervalStarted, notifier.EventIntervalSkipped}, events.Received())
+	suite.Equal(metrics.SkipReasonWeekday, events.Events[1].Reason)
+	suite.Equal(time.Date(2026, 10, 17, 12, 0, 0, 0, time.UTC), events.Events[1].Time)
+
+	// a dry-run interval
+	reset()
+	chaoskube.Now = func() time.Time { return time.Date(2026, 10, 16, 12, 0, 0, 0, time.UTC) }
+	chaoskube.SetDryRun(true)
+	suite.Require().NoError(chaoskube.TerminateVictims(context.Background()))
+	suite.Equal([]notifier.EventType{notifier.EventIntervalStarted, notifier.EventVictimsSelected, notifier.EventDryRunTermination, notifier.EventIntervalCompleted}, events.Received())
+	suite.Equal(1, events.Events[1].Candidates)
+	suite.Require().Len(events.Events[1].Victims, 1)
+	suite.Equal("foo", events.Events[1].Victims[0].Name)
+	suite.Equal("foo", events.Events[2].Pod.Name)
+	suite.True(events.Events[2].DryRun)
+	suite.True(events.Events[3].DryRun)
+	suite.Require().Len(events.Events[3].Results, 1)
+	suite.Equal("foo", events.Events[3].Results[0].Pod.Name)
+	// every event has its own ID
+	suite.NotEmpty(events.Events[2].ID)
+	suite.NotEqual(events.Events[2].ID, events.Events[3].ID)
+
+	// a terminating interval
+	reset()
+	chaoskube.SetDryRun(false)
+	suite.Require().NoError(chaoskube.TerminateVictims(context.Background()))
+	suite.Equal([]notifier.EventType{notifier.EventIntervalStarted, notifier.EventVictimsSelected, notifier.EventPodTerminated, notifier.EventIntervalCompleted}, events.Received())
+	suite.False(events.Events[2].DryRun)
+	suite.Require().Len(events.Events[3].Results, 1)
+	suite.NoError(events.Events[3].Results[0].Error)
+
+	// the pod is gone now, so terminating it again fails
+	reset()
+	pod := util.NewPod("default", "foo", v1.PodRunning)
+	suite.Error(chaoskube.DeletePod(context.Background(), pod))
+	suite.Equal([]notifier.EventType{notifier.EventTerminationFailed}, events.Received())
+	suite.Error(events.Events[0].Error)
+
+	// only subscribed events are sent
+	reset()
+	events.Subscription = notifier.Subscription{Events: []notifier.EventType{notifier.EventIntervalSkipped}}
+	suite.Require().NoError(chaoskube.TerminateVictims(context.Background()))
+	suite.Equal([]notifier.EventType{notifier.EventIntervalSkipped}, events.Received())
+	suite.Equal(metrics.SkipReasonNoVictim, events.Events[0].Reason)
+}
+
+// TestNotifierRecovery tests that a recovery is reported once the victim's owner has as many ready pods as before.
+func (suite *Suite) TestNotifierRecovery() {
+	defer func(interval time.Duration) { recoveryPollInterval = interval }(recoveryPollInterval)
+	recoveryPollInterval = 10 * time.Millisecond
+
+	chaoskube := suite.setup(
+		labels.Everything(),
+		labels.Everything(),
+		labels.Everything(),
+		labels.Everything(),
+		labels.Everything(),
+		nil,
+		nil,
+		[]time.Weekday{},
+		[]util.TimePeriod{},
+		[]time.Time{},
+		time.UTC,
+		time.Duration(0),
+		false,
+		10,
+		1,
+		v1.NamespaceAll,
+	)
+	chaoskube.RecoveryTimeout = time.Minute
+	events := &notifier.Noop{}
+	chaoskube.Notifier = events
+
+	readyPod := func(name string) v1.Pod {
+		pod := util.NewPodWithOwner("default", name, v1.PodRunning, "owner")
+		pod.UID = types.UID(name)
+		pod.Labels = map[string]string{"app": "foo", "statefulset.kubernetes.io/pod-name": name}
+		pod.Status.Conditions = []v1.PodCondition{{Type: v1.PodReady, Status: v1.ConditionTrue}}
+		_, err := chaoskube.Client.CoreV1().Pods(pod.Namespace).Create(context.Background(), &pod, metav1.CreateOptions{})
+		suite.Require().NoError(err)
+		return pod
+	}
+
+	victim := readyPod("foo-1")
+	readyPod("foo-2")
+
+	// only the victim's siblings are listed
+	client := chaoskube.Client.(*fake.Clientset)
+	client.ClearActions()
+	ready, err := chaoskube.readyReplicas(context.Background(), victim, util.OwnerOf(victim))
+	suite.Require().NoError(err)
+	suite.Equal(1, ready)
+	suite.Require().Len(client.Actions(), 1)
+	suite.Equal("app=foo", client.Actions()[0].(ktesting.ListAction).GetListRestrictions().Labels.String())
+
+	suite.Require().NoError(chaoskube.DeletePod(context.Background(), victim))
+	suite.Equal([]notifier.EventType{notifier.EventPodTerminated}, events.Received())
+
+	// the owner replaces the victim
+	readyPod("foo-3")
+
+	chaoskube.WaitForRecoveries()
+	suite.Equal([]notifier.EventType{notifier.EventPodTerminated, notifier.EventRecoveryCompleted}, events.Received())
+	suite.Equal("foo-1", events.Events[1].Pod.Name)
+}
+
+// TestWarningPeriod tests that terminations are announced and only happen if the victim is still eligible afterwards.
+func (suite *Suite) TestWarningPeriod() {
+	for _, tt := range []struct {
+		name     string
+		meantime func(chaoskube *Chaoskube)
+		events   []notifier.EventType
+		reason   string
+		deleted  bool
+	}{
+		{
+			name:     "nothing changes",
+			meantime: func(chaoskube *Chaoskube) {},
+			events:   []notifier.EventType{notifier.EventIntervalStarted, notifier.EventVictimsSelected, notifier.EventTerminationAnnounced, notifier.EventPodTerminated, notifier.EventIntervalCompleted},
+			deleted:  true,
+		},
+		{
+			name: "pod opts out",
+			meantime: func(chaoskube *Chaoskube) {
+				pod, err := chaoskube.Client.CoreV1().Pods("default").Get(context.Background(), "foo", metav1.GetOptions{})
+				suite.Require().NoError(err)
+				pod.Annotations[AnnotationOptOut] = "true"
+				_, err = chaoskube.Client.CoreV1().Pods("default").Update(context.Background(), pod, metav1.UpdateOptions{})
+				suite.Require().NoError(err)
+			},
+			events: []notifier.EventType{notifier.EventIntervalStarted, notifier.EventVictimsSelected, notifier.EventTerminationAnnounced, notifier.EventTerminationCancelled, notifier.EventIntervalSkipped},
+			reason: "pod opted out with annotation chaoskube.io/opt-out=true",
+		},
+		{
+			name: "pod is gone",
+			meantime: func(chaoskube *Chaoskube) {
+				err := chaoskube.Client.CoreV1().Pods("default").Delete(context.Background(), "foo", metav1.DeleteOptions{})
+				suite.Require().NoError(err)
+			},
+			events:  []notifier.EventType{notifier.EventIntervalStarted, notifier.EventVictimsSelected, notifier.EventTerminationAnnounced, notifier.EventTerminationCancelled, notifier.EventIntervalSkipped},
+			reason:  "pod is gone",
+			deleted: true,
+		},
+		{
+			name: "chaos is paused",
+			meantime: func(chaoskube *Chaoskube) {
+				chaoskube.PauseSwitch.Pause("test", time.Time{})
+			},
+			events: []notifier.EventType{notifier.EventIntervalStarted, notifier.EventVictimsSelected, notifier.EventTerminationAnnounced, notifier.EventTerminationCancelled, notifier.EventIntervalSkipped},
+			reason: "chaos was paused",
+		},
+		{
+			name: "quiet time begins",
+			meantime: func(chaoskube *Chaoskube) {
+				chaoskube.ExcludedWeekdays = []time.Weekday{chaoskube.Now().In(chaoskube.Timezone).Weekday()}
+			},
+			events: []notifier.EventType{notifier.EventIntervalStarted, notifier.EventVictimsSelected, notifier.EventTerminationAnnounced, notifier.EventTerminationCancelled, notifier.EventIntervalSkipped},
+			reason: "quiet time began",
+		},
+		{
+			name: "health check fails",
+			meantime: func(chaoskube *Chaoskube) {
+				chaoskube.HealthCheckers = []health.Checker{
+					staticChecker{&health.Result{Check: health.CheckNotReadyNodes, Message: "too many nodes are not ready"}},
+				}
+			},
+			events: []notifier.EventType{notifier.EventIntervalStarted, notifier.EventVictimsSelected, notifier.EventTerminationAnnounced, notifier.EventTerminationCancelled, notifier.EventIntervalSkipped},
+			reason: "health check failed",
+		},
+	} {
+		chaoskube := suite.setupWithPods(
+			labels.Everything(),
+			labels.Everything(),
+			labels.Everything(),
+			labels.Everything(),
+			labels.Everything(),
+			regexp.MustCompile("foo"),
+			nil,
+			[]time.Weekday{},
+			[]util.TimePeriod{},
+			[]time.Time{},
+			time.UTC,
+			time.Duration(0),
+			false,
+			10,
+			v1.NamespaceAll,
+		)
+		events := &notifier.Noop{}
+		chaoskube.Notifier = events
+		chaoskube.WarningPeriod = 5 * time.Minute
+
+		var slept time.Duration
+		chaoskube.sleep = func(ctx context.Context, d time.Duration) error {
+			slept = d
+			tt.meantime(chaoskube)
+			return nil
+		}
+
+		suite.Require().NoError(chaoskube.TerminateVictims(context.Background()), tt.name)
+
+		suite.Equal(5*time.Minute, slept, tt.name)
+		suite.Equal(tt.events, events.Received(), tt.name)
+		suite.Equal("foo", events.Events[2].Pod.Name, tt.name)
+		suite.Equal(5*time.Minute, events.Events[2].Duration, tt.name)
+		if tt.reason != "" {
+			suite.Equal(tt.reason, events.Events[3].Reason, tt.name)
+		}
+
+		_, err := chaoskube.Client.CoreV1().Pods("default").Get(context.Background(), "foo", metav1.GetOptions{})
+		suite.Equal(tt.deleted, err != nil, tt.name)
+	}
+}
+
+// TestTrigger tests that a triggered run doesn't wait for a run that's in its warning period.
+func (suite *Suite) TestTrigger() {
+	chaoskube := suite.setupWithPods(
+		labels.Everything(),
+		labels.Everything(),
+		labels.Everything(),
+		labels.Everything(),
+		labels.Everything(),
+		&regexp.Regexp{},
+		&regexp.Regexp{},
+		[]time.Weekday{},
+		[]util.TimePeriod{},
+		[]time.Time{},
+		time.UTC,
+		time.Duration(0),
+		true,
+		10,
+		v1.NamespaceAll,
+	)
+	chaoskube.WarningPeriod = 5 * time.Minute
+
+	sleeping, wakeUp := make(chan struct{}), make(chan struct{})
+	chaoskube.sleep = func(ctx context.Context, d time.Duration) error {
+		sleeping <- struct{}{}
+		<-wakeUp
+		return nil
+	}
+
+	done := make(chan error)
+	go func() { done <- chaoskube.TerminateVictims(context.Background()) }()
+	<-sleeping
+
+	suite.Equal(ErrRunInProgress, chaoskube.Trigger(context.Background()))
+
+	close(wakeUp)
+	suite.Require().NoError(<-done)
+
+	go func() { <-sleeping }()
+	suite.NoError(chaoskube.Trigger(context.Background()))
+}
+
+func (suite *Suite) TestOptOutFilter() {
+	optedOut := util.NewPod("default", "foo", v1.PodRunning)
+	optedOut.Annotations[AnnotationOptOut] = "true"
+	optedIn := util.NewPod("default", "bar", v1.PodRunning)
+	optedIn.Annotations[AnnotationOptOut] = "false"
+	unannotated := util.NewPod("default", "baz", v1.PodRunning)
+
+	passed, reason, err := OptOutFilter{}.Filter(context.Background(), []v1.Pod{optedOut, optedIn, unannotated})
+	suite.Require().NoError(err)
+	suite.Equal([]v1.Pod{optedIn, unannotated}, passed)
+	suite.Equal("pod opted out with annotation chaoskube.io/opt-out=true", reason)
+}
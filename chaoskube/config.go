@@ -0,0 +1,123 @@
+package chaoskube
+
+import (
+	"errors"
+	"fmt"
+	"regexp"
+	"time"
+
+	v1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/labels"
+
+	"github.com/linki/chaoskube/util"
+)
+
+// Config holds the settings that control which pods chaoskube terminates and when.
+type Config struct {
+	// a label selector which restricts the pods to choose from
+	Labels labels.Selector
+	// an annotation selector which restricts the pods to choose from
+	Annotations labels.Selector
+	// a kind label selector which restricts the kinds to choose from
+	Kinds labels.Selector
+	// a namespace selector which restricts the pods to choose from
+	Namespaces labels.Selector
+	// a namespace label selector which restricts the namespaces to choose from
+	NamespaceLabels labels.Selector
+	// a regular expression for pod names to include
+	IncludedPodNames *regexp.Regexp
+	// a regular expression for pod names to exclude
+	ExcludedPodNames *regexp.Regexp
+	// a list of weekdays when termination is suspended
+	ExcludedWeekdays []time.Weekday
+	// a list of time periods of a day when termination is suspended
+	ExcludedTimesOfDay []util.TimePeriod
+	// a list of days of a year when termination is suspended
+	ExcludedDaysOfYear []time.Time
+	// the timezone to apply when detecting the current weekday
+	Timezone *time.Location
+	// minimum age of pods to consider
+	MinimumAge time.Duration
+	// dry run will not allow any pod terminations
+	DryRun bool
+	// a namespace selector for namespaces whose pods are terminated even in dry-run mode, none if empty
+	ArmedNamespaces labels.Selector
+	// a namespace label selector for namespaces whose pods are terminated even in dry-run mode, none if empty
+	ArmedNamespaceLabels labels.Selector
+	// whether namespaces can arm themselves with the AnnotationArmed annotation
+	ArmedNamespaceAnnotation bool
+	// the maximum number of pods to terminate per interval
+	MaxKill int
+	// the names of the built-in filters to apply in the given order, all of them in the default order if empty
+	FilterNames []string
+	// namespace scope for the Kubernetes client
+	ClientNamespaceScope string
+}
+
+// DefaultConfig returns a Config that considers all pods in all namespaces and
+// terminates a single pod per interval without any quiet times.
+func DefaultConfig() Config {
+	return Config{
+		Labels:               labels.Everything(),
+		Annotations:          labels.Everything(),
+		Kinds:                labels.Everything(),
+		Namespaces:           labels.Everything(),
+		NamespaceLabels:      labels.Everything(),
+		Timezone:             time.UTC,
+		MaxKill:              1,
+		ClientNamespaceScope: v1.NamespaceAll,
+	}
+}
+
+// Validate returns an error if the Config is incomplete or contains settings chaoskube can't act on.
+func (c Config) Validate() error {
+	for _, selector := range []struct {
+		name     string
+		selector labels.Selector
+	}{
+		{"label", c.Labels},
+		{"annotation", c.Annotations},
+		{"kind", c.Kinds},
+		{"namespace", c.Namespaces},
+		{"namespace label", c.NamespaceLabels},
+	} {
+		if selector.selector == nil {
+			return fmt.Errorf("%s selector must not be nil", selector.name)
+		}
+	}
+
+	// kinds and namespaces only support the inclusion and exclusion of names
+	if _, err := util.NewNameSelector(c.Kinds); err != nil {
+		return fmt.Errorf("invalid kind selector: %w", err)
+	}
+
+	if _, err := util.NewNameSelector(c.Namespaces); err != nil {
+		return fmt.Errorf("invalid namespace selector: %w", err)
+	}
+
+	if c.ArmedNamespaces != nil {
+		if _, err := util.NewNameSelector(c.ArmedNamespaces); err != nil {
+			return fmt.Errorf("invalid armed namespace selector: %w", err)
+		}
+	}
+
+	if len(c.FilterNames) > 0 {
+		if _, err := SelectFilters(DefaultFilters(&Chaoskube{}), c.FilterNames); err != nil {
+			return fmt.Errorf("invalid filters: %w", err)
+		}
+	}
+
+	if c.Timezone == nil {
+		return errors.New("timezone must not be nil")
+	}
+
+	if c.MinimumAge < 0 {
+		return fmt.Errorf("minimum age must not be negative, got %s", c.MinimumAge)
+	}
+
+	if c.MaxKill < 1 {
+		return fmt.Errorf("max kill must be at least 1, got %d", c.MaxKill)
+	}
+
+	return nil
+}
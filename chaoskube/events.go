@@ -0,0 +1,146 @@
+package chaoskube
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	log "github.com/sirupsen/logrus"
+
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes/scheme"
+	"k8s.io/client-go/tools/reference"
+
+	"github.com/linki/chaoskube/metrics"
+	"github.com/linki/chaoskube/terminator"
+	"github.com/linki/chaoskube/util"
+)
+
+// The reasons of the Kubernetes events recorded by chaoskube.
+const (
+	// eventReasonKilling is recorded on a terminated pod.
+	eventReasonKilling = "Killing"
+	// eventReasonTerminated is recorded on the owner and namespace of a terminated pod.
+	eventReasonTerminated = "ChaosTerminated"
+	// eventReasonDryRun is recorded on a pod that would have been terminated in dry-run mode, its owner and namespace.
+	eventReasonDryRun = "ChaosDryRun"
+	// eventReasonFailed is recorded on a pod that couldn't be terminated, its owner and namespace.
+	eventReasonFailed = "ChaosFailed"
+	// eventReasonSkipped is recorded on the objects that caused an interval to be skipped.
+	eventReasonSkipped = "ChaosSkipped"
+)
+
+// recordTermination records the victim's termination, or why it failed, as events on the
+// victim, its owner and its namespace, so that the chaos history outlives the pod.
+func (c *Chaoskube) recordTermination(ctx context.Context, victim v1.Pod, err error) {
+	details := c.terminationDetails()
+
+	eventType, podReason, reason := v1.EventTypeNormal, eventReasonKilling, eventReasonTerminated
+	podMessage := fmt.Sprintf("Pod was terminated by chaoskube to introduce chaos (%s).", details)
+	message := fmt.Sprintf("Pod %s was terminated by chaoskube to introduce chaos (%s).", victim.Name, details)
+	if err != nil {
+		eventType, podReason, reason = v1.EventTypeWarning, eventReasonFailed, eventReasonFailed
+		podMessage = fmt.Sprintf("Pod could not be terminated by chaoskube: %v (%s).", err, details)
+		message = fmt.Sprintf("Pod %s could not be terminated by chaoskube: %v (%s).", victim.Name, err, details)
+	}
+
+	c.recordOnVictim(ctx, victim, eventType, podReason, podMessage, reason, message)
+}
+
+// recordDryRun records that the victim would have been terminated as events on the victim,
+// its owner and its namespace, like recordTermination does for real terminations.
+func (c *Chaoskube) recordDryRun(ctx context.Context, victim v1.Pod) {
+	details := c.terminationDetails()
+	podMessage := fmt.Sprintf("Pod would have been terminated by chaoskube but dry-run mode is enabled (%s).", details)
+	message := fmt.Sprintf("Pod %s would have been terminated by chaoskube but dry-run mode is enabled (%s).", victim.Name, details)
+
+	c.recordOnVictim(ctx, victim, v1.EventTypeNormal, eventReasonDryRun, podMessage, eventReasonDryRun, message)
+}
+
+// recordOnVictim records an event on the victim and, with a message naming the victim,
+// on the victim's owner and namespace.
+func (c *Chaoskube) recordOnVictim(ctx context.Context, victim v1.Pod, eventType, podReason, podMessage, reason, message string) {
+	ref, err := reference.GetReference(scheme.Scheme, &victim)
+	if err != nil {
+		c.Logger.WithField("err", err).Warn("failed to reference pod for event")
+	} else {
+		c.EventRecorder.Event(ref, eventType, podReason, podMessage)
+	}
+
+	if owner := c.ownerReference(ctx, victim); owner != nil {
+		c.EventRecorder.Event(owner, eventType, reason, message)
+	}
+
+	c.EventRecorder.Event(namespaceReference(victim.Namespace), eventType, reason, message)
+}
+
+// recordSkip records a skipped interval on chaoskube's own pod, if known. Only failed health
+// checks are warnings, skipping due to the schedule, a pause or a lack of victims is routine.
+func (c *Chaoskube) recordSkip(reason string) {
+	if c.Self == nil {
+		return
+	}
+	eventType := v1.EventTypeNormal
+	if reason == metrics.SkipReasonHealthCheck {
+		eventType = v1.EventTypeWarning
+	}
+	c.EventRecorder.Event(c.Self, eventType, eventReasonSkipped, "Chaos was skipped by chaoskube: "+reason)
+}
+
+// terminationDetails describes how pods are terminated, e.g. for event messages.
+func (c *Chaoskube) terminationDetails() string {
+	details := []string{"terminator: " + terminator.Name(c.Terminator)}
+	if gracePeriod, ok := terminator.GracePeriod(c.Terminator); ok {
+		if gracePeriod < 0 {
+			details = append(details, "grace period: pod's default")
+		} else {
+			details = append(details, "grace period: "+gracePeriod.String())
+		}
+	}
+	if c.Experiment != "" {
+		details = append(details, "experiment: "+c.Experiment)
+	}
+	return strings.Join(details, ", ")
+}
+
+// ownerReference returns a reference to the workload owning the pod. Pods of a ReplicaSet
+// are attributed to the Deployment owning the ReplicaSet, if any.
+func (c *Chaoskube) ownerReference(ctx context.Context, pod v1.Pod) *v1.ObjectReference {
+	owner := util.OwnerOf(pod)
+	if owner == nil {
+		return nil
+	}
+
+	if owner.Kind == "ReplicaSet" {
+		replicaSet, err := c.Client.AppsV1().ReplicaSets(pod.Namespace).Get(ctx, owner.Name, metav1.GetOptions{})
+		if err != nil {
+			c.Logger.WithFields(log.Fields{
+				"namespace": pod.Namespace,
+				"name":      owner.Name,
+				"err":       err,
+			}).Debug("failed to look up owner of replicaset")
+		} else if deployment := metav1.GetControllerOf(replicaSet); deployment != nil && deployment.Kind == "Deployment" {
+			owner = deployment
+		}
+	}
+
+	return &v1.ObjectReference{
+		APIVersion: owner.APIVersion,
+		Kind:       owner.Kind,
+		Namespace:  pod.Namespace,
+		Name:       owner.Name,
+		UID:        owner.UID,
+	}
+}
+
+// namespaceReference returns a reference to the namespace with the given name.
+// The events are stored in the namespace itself.
+func namespaceReference(name string) *v1.ObjectReference {
+	return &v1.ObjectReference{
+		APIVersion: "v1",
+		Kind:       "Namespace",
+		Namespace:  name,
+		Name:       name,
+	}
+}
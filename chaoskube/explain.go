@@ -0,0 +1,84 @@
+package chaoskube
+
+import (
+	"context"
+	"sort"
+
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// Explanation describes whether a pod is a candidate for termination and, if it isn't, why not.
+type Explanation struct {
+	// the namespace of the pod
+	Namespace string `json:"namespace"`
+	// the name of the pod
+	Name string `json:"name"`
+	// whether the pod passed all filters
+	Candidate bool `json:"candidate"`
+	// the name of the filter that rejected the pod
+	Filter string `json:"filter,omitempty"`
+	// a description of why the filter rejected the pod
+	Reason string `json:"reason,omitempty"`
+}
+
+// Explain runs the same filters as Candidates on all pods within the client's namespace
+// scope and reports for each pod whether it's a candidate or which filter rejected it and why.
+// The result is sorted by namespace and name.
+func (c *Chaoskube) Explain(ctx context.Context) ([]Explanation, error) {
+	podList, err := c.Client.CoreV1().Pods(c.ClientNamespaceScope).List(ctx, metav1.ListOptions{})
+	if err != nil {
+		return nil, err
+	}
+
+	// the label selector is usually applied server-side, do it here to explain its effect as well
+	filters := append([]Filter{LabelFilter{Labels: c.Labels}}, c.filters()...)
+
+	explanations := make(map[string]*Explanation, len(podList.Items))
+	for _, pod := range podList.Items {
+		explanations[podKey(pod)] = &Explanation{Namespace: pod.Namespace, Name: pod.Name, Candidate: true}
+	}
+
+	pods := podList.Items
+	for _, filter := range filters {
+		passed, reason, err := filter.Filter(ctx, pods)
+		if err != nil {
+			return nil, err
+		}
+
+		remaining := make(map[string]bool, len(passed))
+		for _, pod := range passed {
+			remaining[podKey(pod)] = true
+		}
+
+		for _, pod := range pods {
+			if !remaining[podKey(pod)] {
+				explanation := explanations[podKey(pod)]
+				explanation.Candidate = false
+				explanation.Filter = filter.Name()
+				explanation.Reason = reason
+			}
+		}
+
+		pods = passed
+	}
+
+	result := make([]Explanation, 0, len(explanations))
+	for _, explanation := range explanations {
+		result = append(result, *explanation)
+	}
+
+	sort.Slice(result, func(i, j int) bool {
+		if result[i].Namespace != result[j].Namespace {
+			return result[i].Namespace < result[j].Namespace
+		}
+		return result[i].Name < result[j].Name
+	})
+
+	return result, nil
+}
+
+// podKey returns a key that uniquely identifies the given pod.
+func podKey(pod v1.Pod) string {
+	return pod.Namespace + "/" + pod.Name
+}
@@ -0,0 +1,296 @@
+package chaoskube
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+	"strings"
+	"time"
+
+	v1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/client-go/kubernetes"
+)
+
+// Filter is a single stage of the pipeline that narrows down all pods to the candidates for termination.
+// Implement it to add custom rules when embedding chaoskube as a library and set Chaoskube.Filters.
+type Filter interface {
+	// Name returns a short identifier of the filter, e.g. used to explain why a pod was rejected.
+	Name() string
+	// Filter returns the pods that pass the filter and a description of why the others were rejected.
+	Filter(ctx context.Context, pods []v1.Pod) ([]v1.Pod, string, error)
+}
+
+// FilterFunc is a function that can be used as a Filter with NewFilter.
+type FilterFunc func(ctx context.Context, pods []v1.Pod) ([]v1.Pod, string, error)
+
+// NewFilter returns a Filter with the given name that's implemented by the given function.
+func NewFilter(name string, filter FilterFunc) Filter {
+	return namedFilter{name: name, filter: filter}
+}
+
+type namedFilter struct {
+	name   string
+	filter FilterFunc
+}
+
+// Name returns the name the filter was created with.
+func (f namedFilter) Name() string {
+	return f.name
+}
+
+// Filter calls the filter's function.
+func (f namedFilter) Filter(ctx context.Context, pods []v1.Pod) ([]v1.Pod, string, error) {
+	return f.filter(ctx, pods)
+}
+
+// LabelFilter keeps pods whose labels match the selector.
+type LabelFilter struct {
+	Labels labels.Selector
+}
+
+// Name returns "labels".
+func (f LabelFilter) Name() string {
+	return "labels"
+}
+
+// Filter keeps pods whose labels match the selector.
+func (f LabelFilter) Filter(_ context.Context, pods []v1.Pod) ([]v1.Pod, string, error) {
+	return filterByLabels(pods, f.Labels), fmt.Sprintf("labels don't match selector %q", f.Labels.String()), nil
+}
+
+// NamespaceFilter keeps pods whose namespace matches the selector.
+type NamespaceFilter struct {
+	Namespaces labels.Selector
+}
+
+// Name returns "namespaces".
+func (f NamespaceFilter) Name() string {
+	return "namespaces"
+}
+
+// Filter keeps pods whose namespace matches the selector.
+func (f NamespaceFilter) Filter(_ context.Context, pods []v1.Pod) ([]v1.Pod, string, error) {
+	filtered, err := filterByNamespaces(pods, f.Namespaces)
+	return filtered, fmt.Sprintf("namespace doesn't match selector %q", f.Namespaces.String()), err
+}
+
+// NamespaceLabelFilter keeps pods whose namespace has labels matching the selector.
+type NamespaceLabelFilter struct {
+	Client          kubernetes.Interface
+	NamespaceLabels labels.Selector
+}
+
+// Name returns "namespaceLabels".
+func (f NamespaceLabelFilter) Name() string {
+	return "namespaceLabels"
+}
+
+// Filter keeps pods whose namespace has labels matching the selector.
+func (f NamespaceLabelFilter) Filter(ctx context.Context, pods []v1.Pod) ([]v1.Pod, string, error) {
+	filtered, err := filterPodsByNamespaceLabels(ctx, pods, f.NamespaceLabels, f.Client)
+	return filtered, fmt.Sprintf("namespace labels don't match selector %q", f.NamespaceLabels.String()), err
+}
+
+// KindFilter keeps pods whose owner kinds match the selector.
+type KindFilter struct {
+	Kinds labels.Selector
+}
+
+// Name returns "kinds".
+func (f KindFilter) Name() string {
+	return "kinds"
+}
+
+// Filter keeps pods whose owner kinds match the selector.
+func (f KindFilter) Filter(_ context.Context, pods []v1.Pod) ([]v1.Pod, string, error) {
+	filtered, err := filterByKinds(pods, f.Kinds)
+	return filtered, fmt.Sprintf("owner kinds don't match selector %q", f.Kinds.String()), err
+}
+
+// AnnotationFilter keeps pods whose annotations match the selector.
+type AnnotationFilter struct {
+	Annotations labels.Selector
+}
+
+// Name returns "annotations".
+func (f AnnotationFilter) Name() string {
+	return "annotations"
+}
+
+// Filter keeps pods whose annotations match the selector.
+func (f AnnotationFilter) Filter(_ context.Context, pods []v1.Pod) ([]v1.Pod, string, error) {
+	return filterByAnnotations(pods, f.Annotations), fmt.Sprintf("annotations don't match selector %q", f.Annotations.String()), nil
+}
+
+// AnnotationOptOut is the annotation that excludes a pod from chaos when set to "true".
+// It also cancels an announced termination if the pod gains it during the warning period.
+const AnnotationOptOut = "chaoskube.io/opt-out"
+
+// OptOutFilter removes pods that opted out of chaos with the AnnotationOptOut annotation.
+// It's always applied first and isn't one of the DefaultFilters, so it can't be disabled.
+type OptOutFilter struct{}
+
+// Name returns "optOut".
+func (f OptOutFilter) Name() string {
+	return "optOut"
+}
+
+// Filter removes pods that opted out of chaos with the AnnotationOptOut annotation.
+func (f OptOutFilter) Filter(_ context.Context, pods []v1.Pod) ([]v1.Pod, string, error) {
+	filtered := []v1.Pod{}
+	for _, pod := range pods {
+		if pod.Annotations[AnnotationOptOut] != "true" {
+			filtered = append(filtered, pod)
+		}
+	}
+	return filtered, fmt.Sprintf("pod opted out with annotation %s=true", AnnotationOptOut), nil
+}
+
+// PhaseFilter keeps pods in the given phase, e.g. Running.
+type PhaseFilter struct {
+	Phase v1.PodPhase
+}
+
+// Name returns "phase".
+func (f PhaseFilter) Name() string {
+	return "phase"
+}
+
+// Filter keeps pods in the given phase, e.g. Running.
+func (f PhaseFilter) Filter(_ context.Context, pods []v1.Pod) ([]v1.Pod, string, error) {
+	return filterByPhase(pods, f.Phase), fmt.Sprintf("pod isn't in phase %s", f.Phase), nil
+}
+
+// TerminatingFilter removes pods that are already being terminated.
+type TerminatingFilter struct{}
+
+// Name returns "terminating".
+func (f TerminatingFilter) Name() string {
+	return "terminating"
+}
+
+// Filter removes pods that are already being terminated.
+func (f TerminatingFilter) Filter(_ context.Context, pods []v1.Pod) ([]v1.Pod, string, error) {
+	return filterTerminatingPods(pods), "pod is already terminating", nil
+}
+
+// MinimumAgeFilter keeps pods that are older than the minimum age.
+type MinimumAgeFilter struct {
+	MinimumAge time.Duration
+	// a function to retrieve the current time
+	Now func() time.Time
+}
+
+// Name returns "minimumAge".
+func (f MinimumAgeFilter) Name() string {
+	return "minimumAge"
+}
+
+// Filter keeps pods that are older than the minimum age.
+func (f MinimumAgeFilter) Filter(_ context.Context, pods []v1.Pod) ([]v1.Pod, string, error) {
+	return filterByMinimumAge(pods, f.MinimumAge, f.Now()), fmt.Sprintf("pod is younger than %s", f.MinimumAge), nil
+}
+
+// PodNameFilter keeps pods whose name matches IncludedPodNames and doesn't match ExcludedPodNames.
+type PodNameFilter struct {
+	IncludedPodNames *regexp.Regexp
+	ExcludedPodNames *regexp.Regexp
+}
+
+// Name returns "podName".
+func (f PodNameFilter) Name() string {
+	return "podName"
+}
+
+// Filter keeps pods whose name matches IncludedPodNames and doesn't match ExcludedPodNames.
+func (f PodNameFilter) Filter(_ context.Context, pods []v1.Pod) ([]v1.Pod, string, error) {
+	reason := fmt.Sprintf("pod name doesn't match %q or matches %q", regexpString(f.IncludedPodNames), regexpString(f.ExcludedPodNames))
+	return filterByPodName(pods, f.IncludedPodNames, f.ExcludedPodNames), reason, nil
+}
+
+// OwnerReferenceFilter keeps a single random pod of each owner.
+type OwnerReferenceFilter struct{}
+
+// Name returns "ownerReference".
+func (f OwnerReferenceFilter) Name() string {
+	return "ownerReference"
+}
+
+// Filter keeps a single random pod of each owner.
+func (f OwnerReferenceFilter) Filter(_ context.Context, pods []v1.Pod) ([]v1.Pod, string, error) {
+	return filterByOwnerReference(pods), "another pod of the same owner was picked", nil
+}
+
+// DefaultFilters returns the built-in filters configured by the selectors and options of
+// the given chaoskube instance, in the order they are applied.
+// The label selector is left out as it's already applied when listing the pods.
+func DefaultFilters(c *Chaoskube) []Filter {
+	return []Filter{
+		NamespaceFilter{Namespaces: c.Namespaces},
+		NamespaceLabelFilter{Client: c.Client, NamespaceLabels: c.NamespaceLabels},
+		KindFilter{Kinds: c.Kinds},
+		AnnotationFilter{Annotations: c.Annotations},
+		PhaseFilter{Phase: v1.PodRunning},
+		TerminatingFilter{},
+		MinimumAgeFilter{MinimumAge: c.MinimumAge, Now: c.Now},
+		PodNameFilter{IncludedPodNames: c.IncludedPodNames, ExcludedPodNames: c.ExcludedPodNames},
+		OwnerReferenceFilter{},
+	}
+}
+
+// FilterNames returns the names of the built-in filters in their default order.
+func FilterNames() []string {
+	names := []string{}
+	for _, filter := range DefaultFilters(&Chaoskube{}) {
+		names = append(names, filter.Name())
+	}
+	return names
+}
+
+// SelectFilters returns the filters with the given names in the given order, which allows
+// reordering or disabling built-in filters. It returns an error for unknown or repeated names.
+func SelectFilters(filters []Filter, names []string) ([]Filter, error) {
+	byName := make(map[string]Filter, len(filters))
+	for _, filter := range filters {
+		byName[filter.Name()] = filter
+	}
+
+	selected := make([]Filter, 0, len(names))
+	seen := make(map[string]bool, len(names))
+	for _, name := range names {
+		filter, ok := byName[name]
+		if !ok {
+			return nil, fmt.Errorf("unknown filter %q, expected one of %s", name, strings.Join(FilterNames(), ", "))
+		}
+		if seen[name] {
+			return nil, fmt.Errorf("filter %q is given more than once", name)
+		}
+		seen[name] = true
+		selected = append(selected, filter)
+	}
+	return selected, nil
+}
+
+// filters returns the configured filter pipeline or the default one if none is configured.
+// The default pipeline is restricted to and ordered by FilterNames if any are given. Either
+// way the OptOutFilter comes first as pods can always opt out, no matter the configuration.
+func (c *Chaoskube) filters() []Filter {
+	filters := c.Filters
+	if filters == nil {
+		filters = DefaultFilters(c)
+		if len(c.FilterNames) > 0 {
+			// the names are validated by Config.Validate
+			filters, _ = SelectFilters(filters, c.FilterNames)
+		}
+	}
+	return append([]Filter{OptOutFilter{}}, filters...)
+}
+
+// regexpString returns the pattern of the given regular expression or an empty string if it's nil.
+func regexpString(r *regexp.Regexp) string {
+	if r == nil {
+		return ""
+	}
+	return r.String()
+}
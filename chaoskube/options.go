@@ -0,0 +1,281 @@
+package chaoskube
+
+import (
+	"errors"
+	"regexp"
+	"time"
+
+	log "github.com/sirupsen/logrus"
+	"go.opentelemetry.io/otel/trace"
+
+	v1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/tools/record"
+
+	"github.com/linki/chaoskube/audit"
+	"github.com/linki/chaoskube/health"
+	"github.com/linki/chaoskube/metrics"
+	"github.com/linki/chaoskube/notifier"
+	"github.com/linki/chaoskube/pause"
+	"github.com/linki/chaoskube/terminator"
+	"github.com/linki/chaoskube/util"
+)
+
+// Option configures a Chaoskube instance created by NewWithOptions.
+type Option func(*options)
+
+// options collects the settings passed to NewWithOptions.
+type options struct {
+	config          Config
+	logger          log.FieldLogger
+	terminator      terminator.Terminator
+	notifier        notifier.Notifier
+	eventRecorder   record.EventRecorder
+	now             func() time.Time
+	healthCheckers  []health.Checker
+	pauseSwitch     *pause.Switch
+	filters         []Filter
+	metricLabels    *metrics.TerminationLabels
+	tracerProvider  trace.TracerProvider
+	auditSink       audit.Sink
+	recoveryTimeout time.Duration
+	warningPeriod   time.Duration
+	experiment      string
+	self            *v1.ObjectReference
+	namespaceCache  *util.NamespaceCache
+}
+
+// NewWithOptions returns a new instance of Chaoskube that uses the given Kubernetes client.
+// Without any options it starts from DefaultConfig, logs to the standard logger, deletes
+// victims with their own grace period and doesn't notify anyone. It returns an error if
+// the resulting configuration is invalid.
+func NewWithOptions(client kubernetes.Interface, opts ...Option) (*Chaoskube, error) {
+	if client == nil {
+		return nil, errors.New("client must not be nil")
+	}
+
+	o := &options{
+		config: DefaultConfig(),
+		logger: log.StandardLogger(),
+		now:    time.Now,
+	}
+	for _, opt := range opts {
+		opt(o)
+	}
+
+	if err := o.config.Validate(); err != nil {
+		return nil, err
+	}
+
+	if o.terminator == nil {
+		o.terminator = terminator.NewDeletePodTerminator(client, o.logger, -1*time.Second)
+	}
+	if o.notifier == nil {
+		o.notifier = notifier.New()
+	}
+	if o.eventRecorder == nil {
+		o.eventRecorder = newEventRecorder(client, o.config.ClientNamespaceScope)
+	}
+	if o.pauseSwitch == nil {
+		o.pauseSwitch = pause.NewSwitch(o.logger)
+		o.pauseSwitch.Now = o.now
+	}
+
+	c := newChaoskube(client, o.config, o.logger, o.terminator, o.notifier, o.eventRecorder, o.pauseSwitch)
+	c.Now = o.now
+	c.HealthCheckers = o.healthCheckers
+	c.Filters = o.filters
+	c.MetricLabels = o.metricLabels
+	c.AuditSink = o.auditSink
+	c.RecoveryTimeout = o.recoveryTimeout
+	c.WarningPeriod = o.warningPeriod
+	c.Experiment = o.experiment
+	c.Self = o.self
+	if o.namespaceCache != nil {
+		c.NamespaceCache = o.namespaceCache
+	}
+	if o.tracerProvider != nil {
+		c.Tracer = o.tracerProvider.Tracer(tracerName)
+	}
+
+	return c, nil
+}
+
+// WithConfig replaces all settings of the Config at once.
+func WithConfig(config Config) Option {
+	return func(o *options) { o.config = config }
+}
+
+// WithLabels restricts the pods to those matching the label selector.
+func WithLabels(selector labels.Selector) Option {
+	return func(o *options) { o.config.Labels = selector }
+}
+
+// WithAnnotations restricts the pods to those matching the annotation selector.
+func WithAnnotations(selector labels.Selector) Option {
+	return func(o *options) { o.config.Annotations = selector }
+}
+
+// WithKinds restricts the pods to those whose owner kinds match the selector.
+func WithKinds(selector labels.Selector) Option {
+	return func(o *options) { o.config.Kinds = selector }
+}
+
+// WithNamespaces restricts the pods to those in namespaces matching the selector.
+func WithNamespaces(selector labels.Selector) Option {
+	return func(o *options) { o.config.Namespaces = selector }
+}
+
+// WithNamespaceLabels restricts the pods to those in namespaces whose labels match the selector.
+func WithNamespaceLabels(selector labels.Selector) Option {
+	return func(o *options) { o.config.NamespaceLabels = selector }
+}
+
+// WithPodNames restricts the pods to those whose name matches included and doesn't match excluded.
+// Either of them may be nil.
+func WithPodNames(included, excluded *regexp.Regexp) Option {
+	return func(o *options) {
+		o.config.IncludedPodNames = included
+		o.config.ExcludedPodNames = excluded
+	}
+}
+
+// WithQuietTimes suspends terminations on the given weekdays, times of day and days of a year.
+func WithQuietTimes(weekdays []time.Weekday, timesOfDay []util.TimePeriod, daysOfYear []time.Time) Option {
+	return func(o *options) {
+		o.config.ExcludedWeekdays = weekdays
+		o.config.ExcludedTimesOfDay = timesOfDay
+		o.config.ExcludedDaysOfYear = daysOfYear
+	}
+}
+
+// WithTimezone sets the timezone used to evaluate the quiet times.
+func WithTimezone(timezone *time.Location) Option {
+	return func(o *options) { o.config.Timezone = timezone }
+}
+
+// WithMinimumAge ignores pods younger than the given age.
+func WithMinimumAge(minimumAge time.Duration) Option {
+	return func(o *options) { o.config.MinimumAge = minimumAge }
+}
+
+// WithDryRun enables or disables dry-run mode.
+func WithDryRun(dryRun bool) Option {
+	return func(o *options) { o.config.DryRun = dryRun }
+}
+
+// WithArmedNamespaces terminates pods in namespaces matching the selector even in dry-run mode.
+func WithArmedNamespaces(selector labels.Selector) Option {
+	return func(o *options) { o.config.ArmedNamespaces = selector }
+}
+
+// WithArmedNamespaceLabels terminates pods in namespaces whose labels match the selector even in dry-run mode.
+func WithArmedNamespaceLabels(selector labels.Selector) Option {
+	return func(o *options) { o.config.ArmedNamespaceLabels = selector }
+}
+
+// WithArmedNamespaceAnnotation lets namespaces arm themselves with the AnnotationArmed annotation.
+func WithArmedNamespaceAnnotation(enabled bool) Option {
+	return func(o *options) { o.config.ArmedNamespaceAnnotation = enabled }
+}
+
+// WithMaxKill sets the maximum number of pods to terminate per interval.
+func WithMaxKill(maxKill int) Option {
+	return func(o *options) { o.config.MaxKill = maxKill }
+}
+
+// WithClientNamespaceScope restricts the Kubernetes client to a single namespace.
+func WithClientNamespaceScope(namespace string) Option {
+	return func(o *options) { o.config.ClientNamespaceScope = namespace }
+}
+
+// WithLogger sets the logger to write log messages to.
+func WithLogger(logger log.FieldLogger) Option {
+	return func(o *options) { o.logger = logger }
+}
+
+// WithTerminator sets the terminator that terminates victim pods.
+func WithTerminator(terminator terminator.Terminator) Option {
+	return func(o *options) { o.terminator = terminator }
+}
+
+// WithNotifier sets the notifier that is informed about terminated pods.
+func WithNotifier(notifier notifier.Notifier) Option {
+	return func(o *options) { o.notifier = notifier }
+}
+
+// WithEventRecorder sets the recorder used to publish events to Kubernetes.
+func WithEventRecorder(recorder record.EventRecorder) Option {
+	return func(o *options) { o.eventRecorder = recorder }
+}
+
+// WithClock sets the function used to retrieve the current time.
+// It also applies to the default pause switch, but not to one passed with WithPauseSwitch.
+func WithClock(now func() time.Time) Option {
+	return func(o *options) { o.now = now }
+}
+
+// WithHealthCheckers sets the health checks that must pass before any pod is terminated.
+func WithHealthCheckers(checkers ...health.Checker) Option {
+	return func(o *options) { o.healthCheckers = checkers }
+}
+
+// WithPauseSwitch sets the switch that pauses all terminations while it's on.
+func WithPauseSwitch(pauseSwitch *pause.Switch) Option {
+	return func(o *options) { o.pauseSwitch = pauseSwitch }
+}
+
+// WithFilterNames restricts and reorders the built-in filters, see Config.FilterNames.
+func WithFilterNames(names ...string) Option {
+	return func(o *options) { o.config.FilterNames = names }
+}
+
+// WithFilters replaces the default filter pipeline, see Chaoskube.Filters.
+func WithFilters(filters ...Filter) Option {
+	return func(o *options) { o.filters = filters }
+}
+
+// WithMetricLabels sets the optional labels attached to the termination metrics.
+func WithMetricLabels(labels *metrics.TerminationLabels) Option {
+	return func(o *options) { o.metricLabels = labels }
+}
+
+// WithTracerProvider sets the provider of the tracer that records the spans of each run.
+// Without it the global provider registered with otel.SetTracerProvider is used.
+func WithTracerProvider(provider trace.TracerProvider) Option {
+	return func(o *options) { o.tracerProvider = provider }
+}
+
+// WithAuditSink sets the destination for the audit trail of each run.
+func WithAuditSink(sink audit.Sink) Option {
+	return func(o *options) { o.auditSink = sink }
+}
+
+// WithRecoveryTimeout sets how long to wait for the owner of a terminated pod to recover
+// before giving up on sending a recovery notification. Zero disables recovery tracking.
+func WithRecoveryTimeout(timeout time.Duration) Option {
+	return func(o *options) { o.recoveryTimeout = timeout }
+}
+
+// WithWarningPeriod announces each termination for the given duration before it happens.
+// Zero terminates victims right away.
+func WithWarningPeriod(period time.Duration) Option {
+	return func(o *options) { o.warningPeriod = period }
+}
+
+// WithExperiment sets the name of the chaos experiment that is included in the recorded events.
+func WithExperiment(name string) Option {
+	return func(o *options) { o.experiment = name }
+}
+
+// WithSelf sets the pod chaoskube runs in. Skipped intervals are recorded as events on it.
+func WithSelf(ref *v1.ObjectReference) Option {
+	return func(o *options) { o.self = ref }
+}
+
+// WithNamespaceCache sets the cache to look up namespaces in, e.g. to share it with the notifiers.
+// By default each instance caches namespaces for util.DefaultNamespaceCacheTTL.
+func WithNamespaceCache(cache *util.NamespaceCache) Option {
+	return func(o *options) { o.namespaceCache = cache }
+}
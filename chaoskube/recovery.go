@@ -0,0 +1,150 @@
+package chaoskube
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	log "github.com/sirupsen/logrus"
+
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+
+	"github.com/linki/chaoskube/notifier"
+	"github.com/linki/chaoskube/util"
+)
+
+// recoveryPollInterval is how often the owner of a terminated pod is checked for recovery.
+var recoveryPollInterval = 5 * time.Second
+
+// podSpecificLabels are set by controllers to tell apart the pods of the same owner. They are
+// ignored when looking for the victim's siblings.
+var podSpecificLabels = []string{
+	"apps.kubernetes.io/pod-index",
+	"batch.kubernetes.io/job-completion-index",
+	"controller-revision-hash",
+	"statefulset.kubernetes.io/pod-name",
+}
+
+// readyBeforeTermination returns the number of ready pods the victim's owner has, including
+// the victim. It returns false if recovery isn't tracked for the victim, i.e. because it's
+// disabled, nobody subscribed to recovery events, the victim has no owner or the pods
+// couldn't be listed.
+func (c *Chaoskube) readyBeforeTermination(ctx context.Context, victim v1.Pod) (int, bool) {
+	if c.RecoveryTimeout <= 0 || !c.Notifier.Subscribes(notifier.EventRecoveryCompleted) {
+		return 0, false
+	}
+	owner := util.OwnerOf(victim)
+	if owner == nil {
+		return 0, false
+	}
+
+	ready, err := c.readyReplicas(ctx, victim, owner)
+	if err != nil {
+		c.Logger.WithField("err", err).Warn("failed to track recovery")
+		return 0, false
+	}
+	if isPodReady(victim) {
+		ready++
+	}
+	return ready, true
+}
+
+// watchRecovery waits in the background until the victim's owner has as many ready pods as
+// before the termination and then notifies about the completed recovery. It gives up after
+// RecoveryTimeout or when ctx is cancelled.
+func (c *Chaoskube) watchRecovery(ctx context.Context, victim v1.Pod, readyBefore int) {
+	owner := util.OwnerOf(victim)
+	start := c.Now()
+
+	c.recoveries.Add(1)
+	go func() {
+		defer c.recoveries.Done()
+
+		ctx, cancel := context.WithTimeout(ctx, c.RecoveryTimeout)
+		defer cancel()
+
+		ticker := time.NewTicker(recoveryPollInterval)
+		defer ticker.Stop()
+
+		logger := c.Logger.WithFields(log.Fields{
+			"namespace": victim.Namespace,
+			"name":      victim.Name,
+		})
+
+		for {
+			select {
+			case <-ctx.Done():
+				if errors.Is(ctx.Err(), context.DeadlineExceeded) {
+					logger.WithField("timeout", c.RecoveryTimeout).Warn("owner of terminated pod did not recover in time")
+				}
+				return
+			case <-ticker.C:
+			}
+
+			ready, err := c.readyReplicas(ctx, victim, owner)
+			if err != nil {
+				logger.WithField("err", err).Debug("failed to check recovery")
+				continue
+			}
+			if ready < readyBefore {
+				continue
+			}
+
+			duration := c.Now().Sub(start)
+			logger.WithField("duration", duration).Info("owner of terminated pod recovered")
+			c.notify(ctx, notifier.Event{Type: notifier.EventRecoveryCompleted, Pod: &victim, Duration: duration})
+			return
+		}
+	}()
+}
+
+// WaitForRecoveries blocks until all recovery watchers have finished.
+func (c *Chaoskube) WaitForRecoveries() {
+	c.recoveries.Wait()
+}
+
+// readyReplicas returns the number of ready pods, other than the victim, that belong to the given owner.
+// Only pods that share the victim's labels are listed, so polling doesn't fetch the whole namespace.
+func (c *Chaoskube) readyReplicas(ctx context.Context, victim v1.Pod, owner *metav1.OwnerReference) (int, error) {
+	listOptions := metav1.ListOptions{LabelSelector: siblingSelector(victim).String()}
+	pods, err := c.Client.CoreV1().Pods(victim.Namespace).List(ctx, listOptions)
+	if err != nil {
+		return 0, err
+	}
+
+	ready := 0
+	for _, pod := range pods.Items {
+		if pod.UID == victim.UID || pod.DeletionTimestamp != nil || !isPodReady(pod) {
+			continue
+		}
+		if other := util.OwnerOf(pod); other != nil && other.UID == owner.UID {
+			ready++
+		}
+	}
+	return ready, nil
+}
+
+// siblingSelector returns a selector for the pods that carry the same labels as the victim,
+// ignoring the labels that differ between pods of the same owner.
+func siblingSelector(victim v1.Pod) labels.Selector {
+	set := labels.Set{}
+	for key, value := range victim.Labels {
+		set[key] = value
+	}
+	for _, key := range podSpecificLabels {
+		delete(set, key)
+	}
+	return labels.SelectorFromSet(set)
+}
+
+// isPodReady returns whether the pod's Ready condition is true.
+func isPodReady(pod v1.Pod) bool {
+	for _, condition := range pod.Status.Conditions {
+		if condition.Type == v1.PodReady {
+			return condition.Status == v1.ConditionTrue
+		}
+	}
+	return false
+}
@@ -0,0 +1,56 @@
+package chaoskube
+
+import (
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/trace"
+
+	v1 "k8s.io/api/core/v1"
+)
+
+// tracerName identifies the spans created by chaoskube.
+const tracerName = "github.com/linki/chaoskube"
+
+// Attributes attached to the spans created by chaoskube.
+const (
+	attributeNamespace  = attribute.Key("k8s.namespace.name")
+	attributePod        = attribute.Key("k8s.pod.name")
+	attributeNode       = attribute.Key("k8s.node.name")
+	attributeFilter     = attribute.Key("chaoskube.filter")
+	attributePodsIn     = attribute.Key("chaoskube.pods.in")
+	attributePodsOut    = attribute.Key("chaoskube.pods.out")
+	attributeVictims    = attribute.Key("chaoskube.victims")
+	attributeCheck      = attribute.Key("chaoskube.check")
+	attributeSkipReason = attribute.Key("chaoskube.skip_reason")
+	attributeTerminator = attribute.Key("chaoskube.terminator")
+	attributeDryRun     = attribute.Key("chaoskube.dry_run")
+	attributeEvent      = attribute.Key("chaoskube.event")
+)
+
+// tracer returns the configured tracer or the globally registered one if none is set,
+// e.g. when Chaoskube is created as a struct literal rather than with NewWithOptions.
+func (c *Chaoskube) tracer() trace.Tracer {
+	if c.Tracer == nil {
+		return otel.Tracer(tracerName)
+	}
+	return c.Tracer
+}
+
+// podAttributes returns the span attributes identifying the given pod.
+func podAttributes(pod v1.Pod) []attribute.KeyValue {
+	return []attribute.KeyValue{
+		attributeNamespace.String(pod.Namespace),
+		attributePod.String(pod.Name),
+		attributeNode.String(pod.Spec.NodeName),
+	}
+}
+
+// endSpan records the given error, if any, on the span and ends it.
+func endSpan(span trace.Span, err error) {
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	span.End()
+}
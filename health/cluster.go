@@ -0,0 +1,252 @@
+package health
+
+import (
+	"context"
+	"fmt"
+
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/kubernetes"
+
+	"github.com/linki/chaoskube/util"
+)
+
+// Names of the cluster health checks as reported in Result.Check.
+const (
+	// CheckNotReadyNodes fails if too many nodes are not ready.
+	CheckNotReadyNodes = "not-ready-nodes"
+	// CheckUnhealthyPods fails if too many pods are pending or crash looping.
+	CheckUnhealthyPods = "unhealthy-pods"
+	// CheckUnavailableReplicas fails if a workload has too many unavailable replicas.
+	CheckUnavailableReplicas = "unavailable-replicas"
+)
+
+// NodeChecker considers the cluster unhealthy if too many nodes are not ready.
+type NodeChecker struct {
+	Client      kubernetes.Interface
+	MaxNotReady int
+}
+
+// NewNodeChecker creates and returns a NodeChecker object.
+func NewNodeChecker(client kubernetes.Interface, maxNotReady int) *NodeChecker {
+	return &NodeChecker{
+		Client:      client,
+		MaxNotReady: maxNotReady,
+	}
+}
+
+// Check counts the nodes whose Ready condition isn't true.
+func (c *NodeChecker) Check(ctx context.Context) (*Result, error) {
+	nodes, err := c.Client.CoreV1().Nodes().List(ctx, metav1.ListOptions{})
+	if err != nil {
+		return nil, err
+	}
+
+	notReady := []v1.Node{}
+	for _, node := range nodes.Items {
+		if !isNodeReady(node) {
+			notReady = append(notReady, node)
+		}
+	}
+
+	if len(notReady) <= c.MaxNotReady {
+		return nil, nil
+	}
+
+	return &Result{
+		Check:   CheckNotReadyNodes,
+		Message: fmt.Sprintf("%d nodes are not ready, at most %d allowed", len(notReady), c.MaxNotReady),
+		Object:  &notReady[0],
+	}, nil
+}
+
+// PodChecker considers the cluster unhealthy if too many pods in the
+// candidate namespaces are pending or crash looping.
+type PodChecker struct {
+	Client          kubernetes.Interface
+	Namespaces      labels.Selector
+	NamespaceLabels labels.Selector
+	NamespaceScope  string
+	MaxUnhealthy    int
+}
+
+// NewPodChecker creates and returns a PodChecker object.
+func NewPodChecker(client kubernetes.Interface, namespaces, namespaceLabels labels.Selector, namespaceScope string, maxUnhealthy int) *PodChecker {
+	return &PodChecker{
+		Client:          client,
+		Namespaces:      namespaces,
+		NamespaceLabels: namespaceLabels,
+		NamespaceScope:  namespaceScope,
+		MaxUnhealthy:    maxUnhealthy,
+	}
+}
+
+// Check counts the pods that are either pending or in CrashLoopBackOff.
+func (c *PodChecker) Check(ctx context.Context) (*Result, error) {
+	selector, err := candidateNamespaces(ctx, c.Client, c.Namespaces, c.NamespaceLabels)
+	if err != nil {
+		return nil, err
+	}
+
+	pods, err := c.Client.CoreV1().Pods(c.NamespaceScope).List(ctx, metav1.ListOptions{})
+	if err != nil {
+		return nil, err
+	}
+
+	unhealthy := []v1.Pod{}
+	for _, pod := range pods.Items {
+		if selector.Matches(pod.Namespace) && isPodUnhealthy(pod) {
+			unhealthy = append(unhealthy, pod)
+		}
+	}
+
+	if len(unhealthy) <= c.MaxUnhealthy {
+		return nil, nil
+	}
+
+	return &Result{
+		Check:   CheckUnhealthyPods,
+		Message: fmt.Sprintf("%d pods are pending or crash looping, at most %d allowed", len(unhealthy), c.MaxUnhealthy),
+		Object:  &unhealthy[0],
+	}, nil
+}
+
+// ReplicaChecker considers the cluster unhealthy if any Deployment, StatefulSet
+// or DaemonSet in the candidate namespaces has too many unavailable replicas.
+type ReplicaChecker struct {
+	Client          kubernetes.Interface
+	Namespaces      labels.Selector
+	NamespaceLabels labels.Selector
+	NamespaceScope  string
+	MaxUnavailable  int
+}
+
+// NewReplicaChecker creates and returns a ReplicaChecker object.
+func NewReplicaChecker(client kubernetes.Interface, namespaces, namespaceLabels labels.Selector, namespaceScope string, maxUnavailable int) *ReplicaChecker {
+	return &ReplicaChecker{
+		Client:          client,
+		Namespaces:      namespaces,
+		NamespaceLabels: namespaceLabels,
+		NamespaceScope:  namespaceScope,
+		MaxUnavailable:  maxUnavailable,
+	}
+}
+
+// Check returns a Result for the first owner that has too many unavailable replicas.
+func (c *ReplicaChecker) Check(ctx context.Context) (*Result, error) {
+	selector, err := candidateNamespaces(ctx, c.Client, c.Namespaces, c.NamespaceLabels)
+	if err != nil {
+		return nil, err
+	}
+
+	apps := c.Client.AppsV1()
+
+	deployments, err := apps.Deployments(c.NamespaceScope).List(ctx, metav1.ListOptions{})
+	if err != nil {
+		return nil, err
+	}
+	for i, d := range deployments.Items {
+		if selector.Matches(d.Namespace) && int(d.Status.UnavailableReplicas) > c.MaxUnavailable {
+			return c.result(&deployments.Items[i], "Deployment", d.Namespace, d.Name, int(d.Status.UnavailableReplicas)), nil
+		}
+	}
+
+	statefulSets, err := apps.StatefulSets(c.NamespaceScope).List(ctx, metav1.ListOptions{})
+	if err != nil {
+		return nil, err
+	}
+	for i, s := range statefulSets.Items {
+		desired := 1
+		if s.Spec.Replicas != nil {
+			desired = int(*s.Spec.Replicas)
+		}
+		unavailable := desired - int(s.Status.AvailableReplicas)
+		if selector.Matches(s.Namespace) && unavailable > c.MaxUnavailable {
+			return c.result(&statefulSets.Items[i], "StatefulSet", s.Namespace, s.Name, unavailable), nil
+		}
+	}
+
+	daemonSets, err := apps.DaemonSets(c.NamespaceScope).List(ctx, metav1.ListOptions{})
+	if err != nil {
+		return nil, err
+	}
+	for i, d := range daemonSets.Items {
+		if selector.Matches(d.Namespace) && int(d.Status.NumberUnavailable) > c.MaxUnavailable {
+			return c.result(&daemonSets.Items[i], "DaemonSet", d.Namespace, d.Name, int(d.Status.NumberUnavailable)), nil
+		}
+	}
+
+	return nil, nil
+}
+
+func (c *ReplicaChecker) result(object runtime.Object, kind, namespace, name string, unavailable int) *Result {
+	return &Result{
+		Check:   CheckUnavailableReplicas,
+		Message: fmt.Sprintf("%s %s/%s has %d unavailable replicas, at most %d allowed", kind, namespace, name, unavailable, c.MaxUnavailable),
+		Object:  object,
+	}
+}
+
+// namespaceSelector matches the names of the namespaces pods are picked from.
+type namespaceSelector interface {
+	Matches(name string) bool
+}
+
+// namespaceSet matches the names of a fixed set of namespaces.
+type namespaceSet map[string]bool
+
+func (s namespaceSet) Matches(name string) bool {
+	return s[name]
+}
+
+// candidateNamespaces returns a selector for the namespaces that match both the name selector
+// and, unless it's empty, the label selector, like the namespace filters of chaoskube do.
+func candidateNamespaces(ctx context.Context, client kubernetes.Interface, namespaces, namespaceLabels labels.Selector) (namespaceSelector, error) {
+	selector, err := util.NewNameSelector(namespaces)
+	if err != nil {
+		return nil, err
+	}
+	if namespaceLabels == nil || namespaceLabels.Empty() {
+		return selector, nil
+	}
+
+	list, err := client.CoreV1().Namespaces().List(ctx, metav1.ListOptions{LabelSelector: namespaceLabels.String()})
+	if err != nil {
+		return nil, err
+	}
+	set := namespaceSet{}
+	for _, namespace := range list.Items {
+		if selector.Matches(namespace.Name) {
+			set[namespace.Name] = true
+		}
+	}
+	return set, nil
+}
+
+// isNodeReady returns true iff the node's Ready condition is true.
+func isNodeReady(node v1.Node) bool {
+	for _, condition := range node.Status.Conditions {
+		if condition.Type == v1.NodeReady {
+			return condition.Status == v1.ConditionTrue
+		}
+	}
+	return false
+}
+
+// isPodUnhealthy returns true iff the pod is pending or one of its containers is crash looping.
+func isPodUnhealthy(pod v1.Pod) bool {
+	if pod.Status.Phase == v1.PodPending {
+		return true
+	}
+
+	for _, statuses := range [][]v1.ContainerStatus{pod.Status.InitContainerStatuses, pod.Status.ContainerStatuses} {
+		for _, status := range statuses {
+			if status.State.Waiting != nil && status.State.Waiting.Reason == "CrashLoopBackOff" {
+				return true
+			}
+		}
+	}
+	return false
+}
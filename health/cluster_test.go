@@ -0,0 +1,219 @@
+package health
+
+import (
+	"context"
+	"testing"
+
+	appsv1 "k8s.io/api/apps/v1"
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/kubernetes/fake"
+
+	"github.com/linki/chaoskube/internal/testutil"
+	"github.com/linki/chaoskube/util"
+
+	"github.com/stretchr/testify/suite"
+)
+
+type ClusterSuite struct {
+	testutil.TestSuite
+}
+
+func (suite *ClusterSuite) TestInterface() {
+	suite.Implements((*Checker)(nil), new(NodeChecker))
+	suite.Implements((*Checker)(nil), new(PodChecker))
+	suite.Implements((*Checker)(nil), new(ReplicaChecker))
+}
+
+func (suite *ClusterSuite) TestNodeChecker() {
+	client := fake.NewSimpleClientset(
+		newNode("ready", v1.ConditionTrue),
+		newNode("not-ready", v1.ConditionFalse),
+		newNode("unknown", v1.ConditionUnknown),
+	)
+
+	for _, tt := range []struct {
+		maxNotReady int
+		healthy     bool
+	}{
+		{0, false},
+		{1, false},
+		{2, true},
+	} {
+		result, err := NewNodeChecker(client, tt.maxNotReady).Check(context.Background())
+		suite.Require().NoError(err)
+
+		if tt.healthy {
+			suite.Nil(result)
+		} else {
+			suite.Require().NotNil(result)
+			suite.Equal(CheckNotReadyNodes, result.Check)
+			suite.Contains(result.Message, "2 nodes are not ready")
+		}
+	}
+}
+
+func (suite *ClusterSuite) TestPodChecker() {
+	pending := util.NewPod("default", "pending", v1.PodPending)
+	crashing := util.NewPod("testing", "crashing", v1.PodRunning)
+	crashing.Status.ContainerStatuses = []v1.ContainerStatus{{
+		State: v1.ContainerState{Waiting: &v1.ContainerStateWaiting{Reason: "CrashLoopBackOff"}},
+	}}
+	running := util.NewPod("default", "running", v1.PodRunning)
+
+	client := fake.NewSimpleClientset(&pending, &crashing, &running)
+
+	for _, tt := range []struct {
+		namespaces   string
+		maxUnhealthy int
+		healthy      bool
+	}{
+		{"", 0, false},
+		{"", 1, false},
+		{"", 2, true},
+		{"default", 0, false},
+		{"default", 1, true},
+		{"!default,!testing", 0, true},
+	} {
+		namespaces, err := labels.Parse(tt.namespaces)
+		suite.Require().NoError(err)
+
+		result, err := NewPodChecker(client, namespaces, labels.Everything(), v1.NamespaceAll, tt.maxUnhealthy).Check(context.Background())
+		suite.Require().NoError(err)
+
+		if tt.healthy {
+			suite.Nil(result)
+		} else {
+			suite.Require().NotNil(result)
+			suite.Equal(CheckUnhealthyPods, result.Check)
+		}
+	}
+}
+
+func (suite *ClusterSuite) TestReplicaChecker() {
+	for _, tt := range []struct {
+		objects        []runtime.Object
+		namespaces     string
+		maxUnavailable int
+		message        string
+	}{
+		{
+			[]runtime.Object{},
+			"",
+			0,
+			"",
+		},
+		{
+			[]runtime.Object{newDeployment("default", "foo", 1)},
+			"",
+			0,
+			"Deployment default/foo has 1 unavailable replicas, at most 0 allowed",
+		},
+		{
+			[]runtime.Object{newDeployment("default", "foo", 1)},
+			"",
+			1,
+			"",
+		},
+		{
+			[]runtime.Object{newDeployment("default", "foo", 1)},
+			"!default",
+			0,
+			"",
+		},
+		{
+			[]runtime.Object{newStatefulSet("testing", "bar", 3, 1)},
+			"",
+			1,
+			"StatefulSet testing/bar has 2 unavailable replicas, at most 1 allowed",
+		},
+		{
+			[]runtime.Object{newDaemonSet("testing", "baz", 1)},
+			"testing",
+			0,
+			"DaemonSet testing/baz has 1 unavailable replicas, at most 0 allowed",
+		},
+	} {
+		namespaces, err := labels.Parse(tt.namespaces)
+		suite.Require().NoError(err)
+
+		client := fake.NewSimpleClientset(tt.objects...)
+
+		result, err := NewReplicaChecker(client, namespaces, labels.Everything(), v1.NamespaceAll, tt.maxUnavailable).Check(context.Background())
+		suite.Require().NoError(err)
+
+		if tt.message == "" {
+			suite.Nil(result)
+		} else {
+			suite.Require().NotNil(result)
+			suite.Equal(CheckUnavailableReplicas, result.Check)
+			suite.Equal(tt.message, result.Message)
+			suite.Equal(tt.objects[0], result.Object)
+		}
+	}
+}
+
+func (suite *ClusterSuite) TestNamespaceLabels() {
+	crashing := util.NewPod("testing", "crashing", v1.PodPending)
+	client := fake.NewSimpleClientset(
+		&v1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: "default", Labels: map[string]string{"env": "prod"}}},
+		&v1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: "testing"}},
+		&crashing,
+		newDeployment("testing", "foo", 1),
+	)
+	prod := labels.SelectorFromSet(labels.Set{"env": "prod"})
+
+	for _, tt := range []struct {
+		namespaceLabels labels.Selector
+		healthy         bool
+	}{
+		{labels.Everything(), false},
+		{prod, true},
+	} {
+		for _, checker := range []Checker{
+			NewPodChecker(client, labels.Everything(), tt.namespaceLabels, v1.NamespaceAll, 0),
+			NewReplicaChecker(client, labels.Everything(), tt.namespaceLabels, v1.NamespaceAll, 0),
+		} {
+			result, err := checker.Check(context.Background())
+			suite.Require().NoError(err)
+			suite.Equal(tt.healthy, result == nil, tt.namespaceLabels.String())
+		}
+	}
+}
+
+func TestClusterSuite(t *testing.T) {
+	suite.Run(t, new(ClusterSuite))
+}
+
+func newNode(name string, ready v1.ConditionStatus) *v1.Node {
+	return &v1.Node{
+		ObjectMeta: metav1.ObjectMeta{Name: name},
+		Status: v1.NodeStatus{
+			Conditions: []v1.NodeCondition{{Type: v1.NodeReady, Status: ready}},
+		},
+	}
+}
+
+func newDeployment(namespace, name string, unavailable int32) *appsv1.Deployment {
+	return &appsv1.Deployment{
+		ObjectMeta: metav1.ObjectMeta{Namespace: namespace, Name: name},
+		Status:     appsv1.DeploymentStatus{UnavailableReplicas: unavailable},
+	}
+}
+
+func newStatefulSet(namespace, name string, replicas, available int32) *appsv1.StatefulSet {
+	return &appsv1.StatefulSet{
+		ObjectMeta: metav1.ObjectMeta{Namespace: namespace, Name: name},
+		Spec:       appsv1.StatefulSetSpec{Replicas: &replicas},
+		Status:     appsv1.StatefulSetStatus{AvailableReplicas: available},
+	}
+}
+
+func newDaemonSet(namespace, name string, unavailable int32) *appsv1.DaemonSet {
+	return &appsv1.DaemonSet{
+		ObjectMeta: metav1.ObjectMeta{Namespace: namespace, Name: name},
+		Status:     appsv1.DaemonSetStatus{NumberUnavailable: unavailable},
+	}
+}
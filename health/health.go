@@ -0,0 +1,23 @@
+package health
+
+import (
+	"context"
+
+	"k8s.io/apimachinery/pkg/runtime"
+)
+
+// Result describes why a health check considers the system to be unhealthy.
+type Result struct {
+	// Check is the name of the health check that failed.
+	Check string
+	// Message is a human readable description of the failure.
+	Message string
+	// Object optionally references the Kubernetes object that caused the failure.
+	Object runtime.Object
+}
+
+// Checker is the interface for implementations of health checks that gate chaos.
+type Checker interface {
+	// Check returns nil if it's safe to introduce chaos, otherwise a Result describing why not.
+	Check(ctx context.Context) (*Result, error)
+}
@@ -0,0 +1,123 @@
+package health
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+)
+
+const CheckPrometheus = "prometheus"
+
+// DefaultTimeout is the default timeout for requests against the Prometheus API.
+var DefaultTimeout = 10 * time.Second
+
+// PrometheusChecker evaluates PromQL expressions against a Prometheus server and
+// considers the system unhealthy if any of them returns a truthy value.
+type PrometheusChecker struct {
+	Address string
+	Queries []string
+	Client  *http.Client
+}
+
+type prometheusResponse struct {
+	Status    string `json:"status"`
+	ErrorType string `json:"errorType"`
+	Error     string `json:"error"`
+	Data      struct {
+		ResultType string          `json:"resultType"`
+		Result     json.RawMessage `json:"result"`
+	} `json:"data"`
+}
+
+// NewPrometheusChecker creates and returns a PrometheusChecker object.
+func NewPrometheusChecker(address string, queries []string) *PrometheusChecker {
+	return &PrometheusChecker{
+		Address: address,
+		Queries: queries,
+		Client:  &http.Client{Timeout: DefaultTimeout},
+	}
+}
+
+// Check evaluates all queries and returns a Result for the first one that is truthy.
+// Similar to Prometheus alerting rules, a query is truthy if it returns at least one
+// sample. Scalar results are truthy if they aren't zero.
+func (p *PrometheusChecker) Check(ctx context.Context) (*Result, error) {
+	for _, query := range p.Queries {
+		truthy, err := p.evaluate(ctx, query)
+		if err != nil {
+			return nil, err
+		}
+
+		if truthy {
+			return &Result{
+				Check:   CheckPrometheus,
+				Message: fmt.Sprintf("query %q returned a truthy value", query),
+			}, nil
+		}
+	}
+
+	return nil, nil
+}
+
+func (p *PrometheusChecker) evaluate(ctx context.Context, query string) (bool, error) {
+	endpoint := strings.TrimSuffix(p.Address, "/") + "/api/v1/query?" + url.Values{"query": {query}}.Encode()
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, endpoint, nil)
+	if err != nil {
+		return false, err
+	}
+	req.Header.Add("Accept", "application/json")
+
+	res, err := p.Client.Do(req)
+	if err != nil {
+		return false, err
+	}
+	defer res.Body.Close()
+
+	var response prometheusResponse
+	if err := json.NewDecoder(res.Body).Decode(&response); err != nil {
+		return false, fmt.Errorf("unexpected response with status code %d from prometheus %s: %v", res.StatusCode, p.Address, err)
+	}
+
+	if response.Status != "success" {
+		return false, fmt.Errorf("failed to evaluate query %q: %s: %s", query, response.ErrorType, response.Error)
+	}
+
+	return isTruthy(response.Data.ResultType, response.Data.Result)
+}
+
+// isTruthy interprets the result of a Prometheus query.
+func isTruthy(resultType string, result json.RawMessage) (bool, error) {
+	switch resultType {
+	case "vector", "matrix":
+		var samples []json.RawMessage
+		if err := json.Unmarshal(result, &samples); err != nil {
+			return false, err
+		}
+		return len(samples) > 0, nil
+	case "scalar":
+		var sample []interface{}
+		if err := json.Unmarshal(result, &sample); err != nil {
+			return false, err
+		}
+		if len(sample) != 2 {
+			return false, fmt.Errorf("invalid scalar result: %s", result)
+		}
+		value, ok := sample[1].(string)
+		if !ok {
+			return false, fmt.Errorf("invalid scalar value: %v", sample[1])
+		}
+		parsed, err := strconv.ParseFloat(value, 64)
+		if err != nil {
+			return false, err
+		}
+		return parsed != 0, nil
+	default:
+		return false, fmt.Errorf("unsupported result type: %s", resultType)
+	}
+}
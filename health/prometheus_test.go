@@ -0,0 +1,93 @@
+package health
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/linki/chaoskube/internal/testutil"
+
+	"github.com/stretchr/testify/suite"
+)
+
+type PrometheusSuite struct {
+	testutil.TestSuite
+}
+
+func (suite *PrometheusSuite) TestInterface() {
+	suite.Implements((*Checker)(nil), new(PrometheusChecker))
+}
+
+func (suite *PrometheusSuite) TestCheck() {
+	responses := map[string]string{
+		"empty":       `{"status":"success","data":{"resultType":"vector","result":[]}}`,
+		"firing":      `{"status":"success","data":{"resultType":"vector","result":[{"metric":{"job":"api"},"value":[1435781451.781,"0"]}]}}`,
+		"scalar-zero": `{"status":"success","data":{"resultType":"scalar","result":[1435781451.781,"0"]}}`,
+		"scalar-one":  `{"status":"success","data":{"resultType":"scalar","result":[1435781451.781,"1"]}}`,
+	}
+
+	testServer := httptest.NewServer(http.HandlerFunc(func(res http.ResponseWriter, req *http.Request) {
+		suite.Require().Equal("/api/v1/query", req.URL.Path)
+		res.Header().Set("Content-Type", "application/json")
+		_, err := res.Write([]byte(responses[req.URL.Query().Get("query")]))
+		suite.Require().NoError(err)
+	}))
+	defer testServer.Close()
+
+	for _, tt := range []struct {
+		queries []string
+		healthy bool
+	}{
+		{[]string{}, true},
+		{[]string{"empty"}, true},
+		{[]string{"firing"}, false},
+		{[]string{"scalar-zero"}, true},
+		{[]string{"scalar-one"}, false},
+		{[]string{"empty", "scalar-zero"}, true},
+		{[]string{"empty", "firing"}, false},
+	} {
+		checker := NewPrometheusChecker(testServer.URL, tt.queries)
+
+		result, err := checker.Check(context.Background())
+		suite.Require().NoError(err)
+
+		if tt.healthy {
+			suite.Nil(result)
+		} else {
+			suite.Require().NotNil(result)
+			suite.Equal(CheckPrometheus, result.Check)
+			suite.Contains(result.Message, tt.queries[len(tt.queries)-1])
+		}
+	}
+}
+
+func (suite *PrometheusSuite) TestCheckQueryError() {
+	testServer := httptest.NewServer(http.HandlerFunc(func(res http.ResponseWriter, req *http.Request) {
+		res.WriteHeader(http.StatusBadRequest)
+		_, err := res.Write([]byte(`{"status":"error","errorType":"bad_data","error":"parse error"}`))
+		suite.Require().NoError(err)
+	}))
+	defer testServer.Close()
+
+	checker := NewPrometheusChecker(testServer.URL, []string{"up =="})
+
+	_, err := checker.Check(context.Background())
+	suite.EqualError(err, `failed to evaluate query "up ==": bad_data: parse error`)
+}
+
+func (suite *PrometheusSuite) TestCheckUnexpectedResponse() {
+	testServer := httptest.NewServer(http.HandlerFunc(func(res http.ResponseWriter, req *http.Request) {
+		res.WriteHeader(http.StatusBadGateway)
+	}))
+	defer testServer.Close()
+
+	checker := NewPrometheusChecker(testServer.URL, []string{"up"})
+
+	_, err := checker.Check(context.Background())
+	suite.Error(err)
+}
+
+func TestPrometheusSuite(t *testing.T) {
+	suite.Run(t, new(PrometheusSuite))
+}
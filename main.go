@@ -12,12 +12,15 @@ import (
 	"path"
 	"regexp"
 	"runtime"
+	"strconv"
+	"strings"
 	"syscall"
 	"time"
 
 	"github.com/alecthomas/kingpin/v2"
 	"github.com/prometheus/client_golang/prometheus/promhttp"
 	log "github.com/sirupsen/logrus"
+	"go.opentelemetry.io/otel"
 
 	v1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/labels"
@@ -26,16 +29,31 @@ import (
 	"k8s.io/client-go/tools/clientcmd"
 	"k8s.io/klog"
 
+	"github.com/linki/chaoskube/api"
+	"github.com/linki/chaoskube/audit"
 	"github.com/linki/chaoskube/chaoskube"
+	"github.com/linki/chaoskube/health"
+	"github.com/linki/chaoskube/metrics"
 	"github.com/linki/chaoskube/notifier"
+	"github.com/linki/chaoskube/pause"
 	"github.com/linki/chaoskube/terminator"
+	"github.com/linki/chaoskube/tracing"
 	"github.com/linki/chaoskube/util"
 )
 
 const envVarPrefix = "CHAOSKUBE_"
 
+const (
+	runCommand        = "run"
+	candidatesCommand = "candidates"
+	explainCommand    = "explain"
+)
+
 var version = "undefined"
 
+// historySize is the number of recent intervals shown on the dashboard.
+const historySize = 50
+
 var (
 	labelString          string
 	annString            string
@@ -51,17 +69,62 @@ var (
 	minimumAge           time.Duration
 	maxRuntime           time.Duration
 	maxKill              int
+	filterNames          string
 	master               string
 	kubeconfig           string
 	interval             time.Duration
 	dryRun               bool
+	armedNsString        string
+	armedNsLabelString   string
+	armedNsAnnotation    bool
 	debug                bool
 	metricsAddress       string
 	gracePeriod          time.Duration
 	logFormat            string
 	logCaller            bool
 	slackWebhook         string
+	slackLinks           []string
+	slackSummary         bool
+	teamsWebhook         string
+	mattermostWebhook    string
+	webhookURL           string
+	cloudEventsSink      string
+	smtpAddress          string
+	smtpFrom             string
+	smtpTo               []string
+	smtpUsername         string
+	smtpPassword         string
+	smtpStartTLS         bool
+	cloudEventsMode      string
+	webhookTemplate      string
+	webhookHeaders       []string
+	webhookSecret        string
+	experiment           string
+	notifyEvents         []string
+	recoveryTimeout      time.Duration
+	notifyBufferSize     int
+	notifyMaxRetries     int
+	notifyRateLimits     []string
+	notifyRouting        string
+	warningPeriod        time.Duration
 	clientNamespaceScope string
+	podName              string
+	podNamespace         string
+	prometheusAddress    string
+	prometheusQueries    []string
+	maxNotReadyNodes     int
+	maxUnhealthyPods     int
+	maxUnavailable       int
+	pauseConfigMap       string
+	apiToken             string
+	output               string
+	explainPod           string
+	metricsLabels        []string
+	metricsMaxValues     int
+	otlpEndpoint         string
+	auditLog             string
+	auditConfigMap       string
+	auditConfigMapSize   int
 )
 
 func cliEnvVar(name string) string {
@@ -86,22 +149,72 @@ func init() {
 	kingpin.Flag("minimum-age", "Minimum age of pods to consider for termination").Envar(cliEnvVar("MINIMUM_AGE")).Default("0s").DurationVar(&minimumAge)
 	kingpin.Flag("max-runtime", "Maximum runtime before chaoskube exits").Envar(cliEnvVar("MAX_RUNTIME")).Default("-1s").DurationVar(&maxRuntime)
 	kingpin.Flag("max-kill", "Specifies the maximum number of pods to be terminated per interval.").Envar(cliEnvVar("MAX_KILL")).Default("1").IntVar(&maxKill)
+	kingpin.Flag("filters", "A comma-separated list of the built-in filters to apply in the given order, e.g. to disable some of them. Defaults to all filters.").Envar(cliEnvVar("FILTERS")).StringVar(&filterNames)
 	kingpin.Flag("master", "The address of the Kubernetes cluster to target").Envar(cliEnvVar("MASTER")).StringVar(&master)
 	kingpin.Flag("kubeconfig", "Path to a kubeconfig file").Envar(cliEnvVar("KUBECONFIG")).StringVar(&kubeconfig)
 	kingpin.Flag("interval", "Interval between Pod terminations").Envar(cliEnvVar("INTERVAL")).Default("10m").DurationVar(&interval)
 	kingpin.Flag("dry-run", "Don't actually kill any pod. Turned on by default. Turn off with `--no-dry-run`.").Envar(cliEnvVar("DRY_RUN")).Default("true").BoolVar(&dryRun)
+	kingpin.Flag("armed-namespaces", "A set of namespaces whose pods are terminated even in dry-run mode. Defaults to none.").Envar(cliEnvVar("ARMED_NAMESPACES")).StringVar(&armedNsString)
+	kingpin.Flag("armed-namespace-labels", "A set of labels of namespaces whose pods are terminated even in dry-run mode. Defaults to none.").Envar(cliEnvVar("ARMED_NAMESPACE_LABELS")).StringVar(&armedNsLabelString)
+	kingpin.Flag("armed-namespace-annotation", "Let namespaces opt in to real terminations despite dry-run mode with the chaoskube.io/armed=true annotation. Disabled by default.").Envar(cliEnvVar("ARMED_NAMESPACE_ANNOTATION")).BoolVar(&armedNsAnnotation)
 	kingpin.Flag("debug", "Enable debug logging.").Envar(cliEnvVar("DEBUG")).BoolVar(&debug)
 	kingpin.Flag("metrics-address", "Listening address for metrics handler").Envar(cliEnvVar("METRICS_ADDRESS")).Default(":8080").StringVar(&metricsAddress)
+	kingpin.Flag("metrics-label", "An optional label to add to the termination metrics. Options are owner_kind, owner_name, node and terminator. Can be repeated.").Envar(cliEnvVar("METRICS_LABEL")).EnumsVar(&metricsLabels, metrics.LabelOwnerKind, metrics.LabelOwnerName, metrics.LabelNode, metrics.LabelTerminator)
+	kingpin.Flag("metrics-max-label-values", "The maximum number of distinct values per optional metrics label. Further values are reported as 'other'. Zero disables the limit.").Envar(cliEnvVar("METRICS_MAX_LABEL_VALUES")).Default("100").IntVar(&metricsMaxValues)
+	kingpin.Flag("otlp-endpoint", "The OTLP/HTTP endpoint to export a trace of each interval to, e.g. http://otel-collector:4318. Disabled by default.").Envar(cliEnvVar("OTLP_ENDPOINT")).StringVar(&otlpEndpoint)
+	kingpin.Flag("audit-log", "Path of a file to append a JSON line for every interval's decision to. Disabled by default.").Envar(cliEnvVar("AUDIT_LOG")).StringVar(&auditLog)
+	kingpin.Flag("audit-configmap", "A ConfigMap in the form namespace/name that keeps the most recent audit records. Disabled by default.").Envar(cliEnvVar("AUDIT_CONFIGMAP")).StringVar(&auditConfigMap)
+	kingpin.Flag("audit-configmap-size", "The number of audit records to keep in the audit ConfigMap.").Envar(cliEnvVar("AUDIT_CONFIGMAP_SIZE")).Default(strconv.Itoa(audit.DefaultConfigMapSize)).IntVar(&auditConfigMapSize)
 	kingpin.Flag("grace-period", "Grace period to terminate Pods. Negative values will use the Pod's grace period.").Envar(cliEnvVar("GRACE_PERIOD")).Default("-1s").DurationVar(&gracePeriod)
 	kingpin.Flag("log-format", "Specify the format of the log messages. Options are text and json. Defaults to text.").Envar(cliEnvVar("LOG_FORMAT")).Default("text").EnumVar(&logFormat, "text", "json")
 	kingpin.Flag("log-caller", "Include the calling function name and location in the log messages.").Envar(cliEnvVar("LOG_CALLER")).BoolVar(&logCaller)
 	kingpin.Flag("slack-webhook", "The address of the slack webhook for notifications").Envar(cliEnvVar("SLACK_WEBHOOK")).StringVar(&slackWebhook)
+	kingpin.Flag("slack-link", "A link added to pods in Slack messages in the form Text=URL, where the URL is a Go template with the same data as the webhook body, e.g. Logs=https://logs.example.com/?q={{ .Namespace }}/{{ .Name }}. Can be repeated.").Envar(cliEnvVar("SLACK_LINK")).StringsVar(&slackLinks)
+	kingpin.Flag("slack-summary", "Send a single Slack message per interval listing all victims instead of one message per terminated pod.").Envar(cliEnvVar("SLACK_SUMMARY")).BoolVar(&slackSummary)
+	kingpin.Flag("teams-webhook", "The address of the Microsoft Teams webhook for notifications").Envar(cliEnvVar("TEAMS_WEBHOOK")).StringVar(&teamsWebhook)
+	kingpin.Flag("mattermost-webhook", "The address of the Mattermost webhook for notifications").Envar(cliEnvVar("MATTERMOST_WEBHOOK")).StringVar(&mattermostWebhook)
+	kingpin.Flag("webhook-url", "The address of a generic webhook to POST a notification to for every terminated pod.").Envar(cliEnvVar("WEBHOOK_URL")).StringVar(&webhookURL)
+	kingpin.Flag("webhook-template", "A Go template rendering the body of the webhook notification. Defaults to a JSON document of all available fields.").Envar(cliEnvVar("WEBHOOK_TEMPLATE")).StringVar(&webhookTemplate)
+	kingpin.Flag("webhook-header", "A header in the form 'Key: Value' to add to webhook notifications. Can be repeated.").Envar(cliEnvVar("WEBHOOK_HEADER")).StringsVar(&webhookHeaders)
+	kingpin.Flag("webhook-secret", "A secret to sign the body of webhook notifications with. The HMAC-SHA256 is sent in the X-Chaoskube-Signature header.").Envar(cliEnvVar("WEBHOOK_SECRET")).StringVar(&webhookSecret)
+	kingpin.Flag("cloudevents-sink", "The address to send CloudEvents about terminated pods, failed terminations and skipped intervals to, e.g. a Knative broker.").Envar(cliEnvVar("CLOUDEVENTS_SINK")).StringVar(&cloudEventsSink)
+	kingpin.Flag("cloudevents-mode", "The content mode of the CloudEvents. Options are structured and binary.").Envar(cliEnvVar("CLOUDEVENTS_MODE")).Default(notifier.CloudEventsModeStructured).EnumVar(&cloudEventsMode, notifier.CloudEventsModeStructured, notifier.CloudEventsModeBinary)
+	kingpin.Flag("smtp-address", "The address of the SMTP server to send email notifications through, e.g. smtp.example.com:587.").Envar(cliEnvVar("SMTP_ADDRESS")).StringVar(&smtpAddress)
+	kingpin.Flag("smtp-from", "The sender address of email notifications.").Envar(cliEnvVar("SMTP_FROM")).Default("chaoskube@localhost").StringVar(&smtpFrom)
+	kingpin.Flag("smtp-to", "A recipient of email notifications. Can be repeated. Namespaces can add recipients with the chaoskube.io/email-recipients annotation.").Envar(cliEnvVar("SMTP_TO")).StringsVar(&smtpTo)
+	kingpin.Flag("smtp-username", "The username to authenticate with the SMTP server.").Envar(cliEnvVar("SMTP_USERNAME")).StringVar(&smtpUsername)
+	kingpin.Flag("smtp-password", "The password to authenticate with the SMTP server.").Envar(cliEnvVar("SMTP_PASSWORD")).StringVar(&smtpPassword)
+	kingpin.Flag("smtp-starttls", "Require upgrading the SMTP connection with STARTTLS. Use --no-smtp-starttls for servers without TLS.").Envar(cliEnvVar("SMTP_STARTTLS")).Default("true").BoolVar(&smtpStartTLS)
+	kingpin.Flag("notify-event", "Subscribe a notifier to an event type in the form notifier:event, e.g. slack:termination.failed, replacing the notifier's default events. Use * as the event to subscribe to all events. Can be repeated.").Envar(cliEnvVar("NOTIFY_EVENT")).StringsVar(&notifyEvents)
+	kingpin.Flag("notify-buffer-size", "The number of notifications to queue per notifier before dropping new ones.").Envar(cliEnvVar("NOTIFY_BUFFER_SIZE")).Default(strconv.Itoa(notifier.DefaultBufferSize)).IntVar(&notifyBufferSize)
+	kingpin.Flag("notify-max-retries", "How often to retry a failed notification with exponential backoff before giving up.").Envar(cliEnvVar("NOTIFY_MAX_RETRIES")).Default(strconv.Itoa(notifier.DefaultMaxRetries)).IntVar(&notifyMaxRetries)
+	kingpin.Flag("notify-rate-limit", "The minimum time between two notifications sent by the same notifier, either as a duration for all notifiers or in the form notifier=duration, e.g. slack=5s, for a single one. Zero disables rate limiting. Can be repeated.").Envar(cliEnvVar("NOTIFY_RATE_LIMIT")).Default(notifier.DefaultRateLimit.String()).StringsVar(&notifyRateLimits)
+	kingpin.Flag("notify-routing", "Route notifications about pods to the webhook or channel annotated on their namespace. Options are off, add to notify the global destination as well and replace to notify only the namespace's destination.").Envar(cliEnvVar("NOTIFY_ROUTING")).Default(notifier.RoutingOff).EnumVar(&notifyRouting, notifier.RoutingOff, notifier.RoutingAdd, notifier.RoutingReplace)
+	kingpin.Flag("warning-period", "Announce each termination this long before it happens and cancel it if the pod opts out or chaos is paused in the meantime. Disabled by default.").Envar(cliEnvVar("WARNING_PERIOD")).Default("0s").DurationVar(&warningPeriod)
+	kingpin.Flag("recovery-timeout", "How long to wait for the owner of a terminated pod to recover before giving up on the recovery.completed event. Zero disables recovery tracking.").Envar(cliEnvVar("RECOVERY_TIMEOUT")).Default("10m").DurationVar(&recoveryTimeout)
+	kingpin.Flag("experiment", "The name of the chaos experiment this instance runs, included in notifications.").Envar(cliEnvVar("EXPERIMENT")).StringVar(&experiment)
+	kingpin.Flag("pod-name", "The name of the pod chaoskube runs in, usually set with the downward API. Skipped intervals are recorded as events on it.").Envar(cliEnvVar("POD_NAME")).StringVar(&podName)
+	kingpin.Flag("pod-namespace", "The namespace of the pod chaoskube runs in, usually set with the downward API.").Envar(cliEnvVar("POD_NAMESPACE")).StringVar(&podNamespace)
 	kingpin.Flag("client-namespace-scope", "Scope Kubernetes API calls to the given namespace. Defaults to v1.NamespaceAll which requires global read permission.").Envar(cliEnvVar("CLIENT_NAMESPACE_SCOPE")).Default(v1.NamespaceAll).StringVar(&clientNamespaceScope)
+	kingpin.Flag("prometheus-address", "The address of the Prometheus server to evaluate health queries against, e.g. http://prometheus:9090").Envar(cliEnvVar("PROMETHEUS_ADDRESS")).StringVar(&prometheusAddress)
+	kingpin.Flag("prometheus-query", "A PromQL expression that suspends termination when it returns a truthy value. Can be repeated.").Envar(cliEnvVar("PROMETHEUS_QUERY")).StringsVar(&prometheusQueries)
+	kingpin.Flag("max-not-ready-nodes", "Suspend termination when more than this number of nodes are not ready. Negative values disable the check.").Envar(cliEnvVar("MAX_NOT_READY_NODES")).Default("-1").IntVar(&maxNotReadyNodes)
+	kingpin.Flag("max-unhealthy-pods", "Suspend termination when more than this number of pods in the candidate namespaces are pending or crash looping. Negative values disable the check.").Envar(cliEnvVar("MAX_UNHEALTHY_PODS")).Default("-1").IntVar(&maxUnhealthyPods)
+	kingpin.Flag("max-unavailable-replicas", "Suspend termination when any Deployment, StatefulSet or DaemonSet in the candidate namespaces has more than this number of unavailable replicas. Negative values disable the check.").Envar(cliEnvVar("MAX_UNAVAILABLE_REPLICAS")).Default("-1").IntVar(&maxUnavailable)
+	kingpin.Flag("pause-configmap", "A ConfigMap in the form namespace/name to watch for a global kill switch. Setting its key 'paused' to 'true' pauses all terminations, optionally until the time given in its key 'until'.").Envar(cliEnvVar("PAUSE_CONFIGMAP")).StringVar(&pauseConfigMap)
+	kingpin.Flag("api-token", "Bearer token that enables and protects the HTTP control API on the metrics address. Disabled by default.").Envar(cliEnvVar("API_TOKEN")).StringVar(&apiToken)
+
+	kingpin.Command(runCommand, "Terminate random pods at the given interval.").Default()
+	kingpin.Command(candidatesCommand, "Print the pods that are currently candidates for termination without terminating any of them.").
+		Flag("output", "Output format of the candidates. Options are table and json.").Short('o').Default(api.OutputTable).EnumVar(&output, api.OutputTable, api.OutputJSON)
+	explain := kingpin.Command(explainCommand, "Print for each pod whether it's a candidate for termination and, if not, which filter rejected it.")
+	explain.Arg("pod", "Only explain the given pod in the form namespace/name.").StringVar(&explainPod)
+	explain.Flag("output", "Output format of the explanations. Options are table and json.").Short('o').Default(api.OutputTable).EnumVar(&output, api.OutputTable, api.OutputJSON)
 }
 
 func main() {
 	kingpin.Version(version)
-	kingpin.Parse()
+	command := kingpin.Parse()
 
 	if debug {
 		log.SetLevel(log.DebugLevel)
@@ -117,30 +230,65 @@ func main() {
 	log.SetReportCaller(logCaller)
 
 	log.WithFields(log.Fields{
-		"labels":               labelString,
-		"annotations":          annString,
-		"kinds":                kindsString,
-		"namespaces":           nsString,
-		"namespaceLabels":      nsLabelString,
-		"includedPodNames":     includedPodNames,
-		"excludedPodNames":     excludedPodNames,
-		"excludedWeekdays":     excludedWeekdays,
-		"excludedTimesOfDay":   excludedTimesOfDay,
-		"excludedDaysOfYear":   excludedDaysOfYear,
-		"timezone":             timezone,
-		"minimumAge":           minimumAge,
-		"maxRuntime":           maxRuntime,
-		"maxKill":              maxKill,
-		"master":               master,
-		"kubeconfig":           kubeconfig,
-		"interval":             interval,
-		"dryRun":               dryRun,
-		"debug":                debug,
-		"metricsAddress":       metricsAddress,
-		"gracePeriod":          gracePeriod,
-		"logFormat":            logFormat,
-		"slackWebhook":         slackWebhook,
-		"clientNamespaceScope": clientNamespaceScope,
+		"labels":                   labelString,
+		"annotations":              annString,
+		"kinds":                    kindsString,
+		"namespaces":               nsString,
+		"namespaceLabels":          nsLabelString,
+		"includedPodNames":         includedPodNames,
+		"excludedPodNames":         excludedPodNames,
+		"excludedWeekdays":         excludedWeekdays,
+		"excludedTimesOfDay":       excludedTimesOfDay,
+		"excludedDaysOfYear":       excludedDaysOfYear,
+		"timezone":                 timezone,
+		"minimumAge":               minimumAge,
+		"maxRuntime":               maxRuntime,
+		"maxKill":                  maxKill,
+		"filters":                  filterNames,
+		"master":                   master,
+		"kubeconfig":               kubeconfig,
+		"interval":                 interval,
+		"dryRun":                   dryRun,
+		"armedNamespaces":          armedNsString,
+		"armedNamespaceLabels":     armedNsLabelString,
+		"armedNamespaceAnnotation": armedNsAnnotation,
+		"debug":                    debug,
+		"metricsAddress":           metricsAddress,
+		"metricsLabels":            metricsLabels,
+		"gracePeriod":              gracePeriod,
+		"logFormat":                logFormat,
+		"slackWebhook":             slackWebhook,
+		"slackLinks":               slackLinks,
+		"slackSummary":             slackSummary,
+		"teamsWebhook":             teamsWebhook,
+		"mattermostWebhook":        mattermostWebhook,
+		"webhookURL":               webhookURL,
+		"webhookHeaders":           len(webhookHeaders),
+		"cloudEventsSink":          cloudEventsSink,
+		"cloudEventsMode":          cloudEventsMode,
+		"smtpAddress":              smtpAddress,
+		"smtpFrom":                 smtpFrom,
+		"smtpTo":                   smtpTo,
+		"smtpUsername":             smtpUsername,
+		"smtpStartTLS":             smtpStartTLS,
+		"experiment":               experiment,
+		"notifyEvents":             notifyEvents,
+		"recoveryTimeout":          recoveryTimeout,
+		"notifyBufferSize":         notifyBufferSize,
+		"notifyMaxRetries":         notifyMaxRetries,
+		"notifyRateLimits":         notifyRateLimits,
+		"notifyRouting":            notifyRouting,
+		"warningPeriod":            warningPeriod,
+		"clientNamespaceScope":     clientNamespaceScope,
+		"podName":                  podName,
+		"podNamespace":             podNamespace,
+		"prometheusAddress":        prometheusAddress,
+		"prometheusQueries":        prometheusQueries,
+		"maxNotReadyNodes":         maxNotReadyNodes,
+		"maxUnhealthyPods":         maxUnhealthyPods,
+		"maxUnavailable":           maxUnavailable,
+		"pauseConfigMap":           pauseConfigMap,
+		"apiEnabled":               apiToken != "",
 	}).Debug("reading config")
 
 	log.WithFields(log.Fields{
@@ -161,6 +309,9 @@ func main() {
 		kinds           = parseSelector(kindsString)
 		namespaces      = parseSelector(nsString)
 		namespaceLabels = parseSelector(nsLabelString)
+
+		armedNamespaces      = parseSelector(armedNsString)
+		armedNamespaceLabels = parseSelector(armedNsLabelString)
 	)
 
 	log.WithFields(log.Fields{
@@ -175,6 +326,14 @@ func main() {
 		"maxKill":          maxKill,
 	}).Info("setting pod filter")
 
+	if dryRun && (!armedNamespaces.Empty() || !armedNamespaceLabels.Empty() || armedNsAnnotation) {
+		log.WithFields(log.Fields{
+			"namespaces":      armedNamespaces.String(),
+			"namespaceLabels": armedNamespaceLabels.String(),
+			"annotation":      armedNsAnnotation,
+		}).Info("arming namespaces despite dry-run mode")
+	}
+
 	parsedWeekdays := util.ParseWeekdays(excludedWeekdays)
 	parsedTimesOfDay, err := util.ParseTimePeriods(excludedTimesOfDay)
 	if err != nil {
@@ -212,32 +371,121 @@ func main() {
 		"offset":   offset / int(time.Hour/time.Second),
 	}).Info("setting timezone")
 
-	notifiers := createNotifier()
-
-	chaoskube := chaoskube.New(
-		client,
-		labelSelector,
-		annotations,
-		kinds,
-		namespaces,
-		namespaceLabels,
-		includedPodNames,
-		excludedPodNames,
-		parsedWeekdays,
-		parsedTimesOfDay,
-		parsedDaysOfYear,
-		parsedTimezone,
-		minimumAge,
-		log.StandardLogger(),
-		dryRun,
-		terminator.NewDeletePodTerminator(client, log.StandardLogger(), gracePeriod),
-		maxKill,
-		notifiers,
-		clientNamespaceScope,
-	)
+	healthCheckers := createHealthCheckers(client, namespaces, namespaceLabels)
+
+	history := audit.NewMemorySink(historySize)
+
+	metricLabels, err := metrics.NewTerminationLabels(metricsLabels, metricsMaxValues)
+	if err != nil {
+		log.WithField("err", err).Fatal("failed to set metrics labels")
+	}
+
+	if otlpEndpoint != "" {
+		tracerProvider, err := tracing.NewTracerProvider(context.Background(), otlpEndpoint, version)
+		if err != nil {
+			log.WithField("err", err).Fatal("failed to set up tracing")
+		}
+		otel.SetTracerProvider(tracerProvider)
+
+		defer func() {
+			ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+			defer cancel()
+			if err := tracerProvider.Shutdown(ctx); err != nil {
+				log.WithField("err", err).Warn("failed to flush traces")
+			}
+		}()
+
+		log.WithField("endpoint", otlpEndpoint).Info("exporting traces")
+	}
+
+	// namespaces are looked up for armed namespaces, routing and email recipients, the cache spares the API server.
+	namespaceCache := util.NewNamespaceCache(client, util.DefaultNamespaceCacheTTL)
+
+	options := []chaoskube.Option{
+		chaoskube.WithConfig(chaoskube.Config{
+			Labels:                   labelSelector,
+			Annotations:              annotations,
+			Kinds:                    kinds,
+			Namespaces:               namespaces,
+			NamespaceLabels:          namespaceLabels,
+			IncludedPodNames:         includedPodNames,
+			ExcludedPodNames:         excludedPodNames,
+			ExcludedWeekdays:         parsedWeekdays,
+			ExcludedTimesOfDay:       parsedTimesOfDay,
+			ExcludedDaysOfYear:       parsedDaysOfYear,
+			Timezone:                 parsedTimezone,
+			MinimumAge:               minimumAge,
+			DryRun:                   dryRun,
+			ArmedNamespaces:          armedNamespaces,
+			ArmedNamespaceLabels:     armedNamespaceLabels,
+			ArmedNamespaceAnnotation: armedNsAnnotation,
+			MaxKill:                  maxKill,
+			FilterNames:              parseFilterNames(filterNames),
+			ClientNamespaceScope:     clientNamespaceScope,
+		}),
+		chaoskube.WithLogger(log.StandardLogger()),
+		chaoskube.WithTerminator(terminator.NewDeletePodTerminator(client, log.StandardLogger(), gracePeriod)),
+		chaoskube.WithHealthCheckers(healthCheckers...),
+		chaoskube.WithMetricLabels(metricLabels),
+		chaoskube.WithRecoveryTimeout(recoveryTimeout),
+		chaoskube.WithWarningPeriod(warningPeriod),
+		chaoskube.WithExperiment(experiment),
+		chaoskube.WithSelf(selfReference()),
+		chaoskube.WithNamespaceCache(namespaceCache),
+	}
+
+	// only running chaos notifies and writes the audit trail, previews don't.
+	if command == runCommand {
+		notifiers := createNotifier(namespaceCache)
+		defer func() {
+			ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+			defer cancel()
+			if err := notifiers.Close(ctx); err != nil {
+				log.WithField("err", err).Warn("failed to flush notifications")
+			}
+		}()
+
+		options = append(options,
+			chaoskube.WithNotifier(notifiers),
+			chaoskube.WithAuditSink(createAuditSink(client, history)),
+		)
+	}
+
+	chaoskube, err := chaoskube.NewWithOptions(client, options...)
+	if err != nil {
+		log.WithField("err", err).Fatal("invalid configuration")
+	}
+
+	if command == explainCommand {
+		printExplanations(chaoskube)
+		return
+	}
+
+	if command == candidatesCommand {
+		printCandidates(chaoskube)
+		return
+	}
+
+	// announced terminations hold up the run, so they must be done before the next tick
+	if warningPeriod >= interval {
+		log.WithFields(log.Fields{
+			"warningPeriod": warningPeriod,
+			"interval":      interval,
+		}).Fatal("warning period must be shorter than the interval")
+	}
+
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	// the ticker fires at fixed multiples of the interval after it was started, regardless
+	// of triggered runs or how long each run takes.
+	started := time.Now()
+	nextRun := func() time.Time {
+		return started.Add((time.Since(started)/interval + 1) * interval)
+	}
 
 	if metricsAddress != "" {
-		go serveMetrics()
+		go serveMetrics(chaoskube, history, nextRun)
 	}
 
 	done := make(chan os.Signal, 1)
@@ -256,10 +504,18 @@ func main() {
 		cancel()
 	}()
 
-	ticker := time.NewTicker(interval)
-	defer ticker.Stop()
+	if pauseConfigMap != "" {
+		namespace, name, found := strings.Cut(pauseConfigMap, "/")
+		if !found {
+			log.WithField("pauseConfigMap", pauseConfigMap).Fatal("failed to parse pause configmap, expected namespace/name")
+		}
+		go pause.NewConfigMapWatcher(client, log.StandardLogger(), namespace, name, chaoskube.PauseSwitch).Run(ctx)
+	}
 
 	chaoskube.Run(ctx, ticker.C)
+
+	// recovery watchers notify as well, so let them finish before the notifiers are closed
+	chaoskube.WaitForRecoveries()
 }
 
 func newClient() (*kubernetes.Clientset, error) {
@@ -308,23 +564,287 @@ func parseSelector(str string) labels.Selector {
 	return selector
 }
 
-func createNotifier() notifier.Notifier {
-	notifiers := notifier.New()
+// parseFilterNames splits the comma-separated filter names, none if empty.
+func parseFilterNames(names string) []string {
+	if strings.TrimSpace(names) == "" {
+		return nil
+	}
+	parsed := []string{}
+	for _, name := range strings.Split(names, ",") {
+		parsed = append(parsed, strings.TrimSpace(name))
+	}
+	return parsed
+}
+
+func createNotifier(namespaces *util.NamespaceCache) *notifier.Dispatcher {
+	subscriptions := parseSubscriptions(notifyEvents)
+	subscribe := func(name string, defaults notifier.Subscription) notifier.Subscription {
+		if subscription, ok := subscriptions[name]; ok {
+			return subscription
+		}
+		return defaults
+	}
+	route := func(n notifier.Routable, annotation string) notifier.Notifier {
+		if notifyRouting == notifier.RoutingOff {
+			return n
+		}
+		return notifier.NewRouter(n, annotation, namespaces, notifyRouting)
+	}
+
+	rateLimit := parseRateLimits(notifyRateLimits)
+
+	notifiers := notifier.NewDispatcher(log.StandardLogger())
+	notifiers.BufferSize = notifyBufferSize
+	notifiers.MaxRetries = notifyMaxRetries
+
 	if slackWebhook != "" {
-		notifiers.Add(notifier.NewSlackNotifier(slackWebhook))
+		slack := notifier.NewSlackNotifier(slackWebhook)
+		slack.Experiment = experiment
+		slack.Links = parseSlackLinks(slackLinks)
+		if slackSummary {
+			slack.Subscription = notifier.Subscription{Events: notifier.SummaryEvents}
+		}
+		slack.Subscription = subscribe(notifier.NotifierSlack, slack.Subscription)
+		notifiers.Add(route(slack, notifier.AnnotationSlackWebhook), rateLimit(notifier.NotifierSlack))
+	}
+	if teamsWebhook != "" {
+		teams := notifier.NewTeamsNotifier(teamsWebhook)
+		teams.Subscription = subscribe(notifier.NotifierTeams, teams.Subscription)
+		notifiers.Add(route(teams, notifier.AnnotationTeamsWebhook), rateLimit(notifier.NotifierTeams))
+	}
+	if mattermostWebhook != "" {
+		mattermost := notifier.NewMattermostNotifier(mattermostWebhook)
+		mattermost.Subscription = subscribe(notifier.NotifierMattermost, mattermost.Subscription)
+		notifiers.Add(route(mattermost, notifier.AnnotationMattermostWebhook), rateLimit(notifier.NotifierMattermost))
+	}
+	if webhookURL != "" {
+		webhook, err := notifier.NewWebhookNotifier(webhookURL, webhookTemplate, parseHeaders(webhookHeaders), webhookSecret, experiment)
+		if err != nil {
+			log.WithField("err", err).Fatal("failed to create webhook notifier")
+		}
+		webhook.Subscription = subscribe(notifier.NotifierWebhook, webhook.Subscription)
+		notifiers.Add(route(webhook, notifier.AnnotationWebhookURL), rateLimit(notifier.NotifierWebhook))
+	}
+	if cloudEventsSink != "" {
+		cloudEvents, err := notifier.NewCloudEventsNotifier(cloudEventsSink, cloudEventsMode, experiment)
+		if err != nil {
+			log.WithField("err", err).Fatal("failed to create cloudevents notifier")
+		}
+		cloudEvents.Subscription = subscribe(notifier.NotifierCloudEvents, cloudEvents.Subscription)
+		notifiers.Add(cloudEvents, rateLimit(notifier.NotifierCloudEvents))
+	}
+	if smtpAddress != "" {
+		email := notifier.NewEmailNotifier(smtpAddress, smtpFrom, smtpTo, smtpUsername, smtpPassword, namespaces, experiment)
+		email.StartTLS = smtpStartTLS
+		email.Subscription = subscribe(notifier.NotifierEmail, email.Subscription)
+		notifiers.Add(email, rateLimit(notifier.NotifierEmail))
 	}
 
 	return notifiers
 }
 
-func serveMetrics() {
+// parseSubscriptions turns notifier:event pairs into a subscription per notifier.
+func parseSubscriptions(pairs []string) map[string]notifier.Subscription {
+	subscriptions := map[string]notifier.Subscription{}
+	for _, pair := range pairs {
+		name, event, found := strings.Cut(pair, ":")
+		if !found {
+			log.WithField("event", pair).Fatal("invalid notify event, expected notifier:event")
+		}
+
+		subscription := subscriptions[name]
+		if event == "*" {
+			subscription.Events = append(subscription.Events, notifier.EventTypes...)
+		} else {
+			eventType, err := notifier.ParseEventType(event)
+			if err != nil {
+				log.WithField("err", err).Fatal("invalid notify event")
+			}
+			subscription.Events = append(subscription.Events, eventType)
+		}
+		subscriptions[name] = subscription
+	}
+	return subscriptions
+}
+
+// parseRateLimits turns durations and notifier=duration pairs into the rate limit of each
+// notifier. A plain duration replaces the default of all notifiers without a pair of their own.
+func parseRateLimits(values []string) func(name string) time.Duration {
+	defaultLimit := notifier.DefaultRateLimit
+	limits := map[string]time.Duration{}
+	for _, value := range values {
+		name, limit, found := strings.Cut(value, "=")
+		if !found {
+			name, limit = "", value
+		}
+
+		duration, err := time.ParseDuration(limit)
+		if err != nil {
+			log.WithField("rateLimit", value).Fatal("invalid notify rate limit, expected duration or notifier=duration")
+		}
+		if name == "" {
+			defaultLimit = duration
+			continue
+		}
+		limits[name] = duration
+	}
+
+	return func(name string) time.Duration {
+		if limit, ok := limits[name]; ok {
+			return limit
+		}
+		return defaultLimit
+	}
+}
+
+// selfReference returns a reference to the pod chaoskube runs in, or nil if it's unknown.
+func selfReference() *v1.ObjectReference {
+	if podName == "" || podNamespace == "" {
+		return nil
+	}
+	return &v1.ObjectReference{APIVersion: "v1", Kind: "Pod", Namespace: podNamespace, Name: podName}
+}
+
+func parseSlackLinks(links []string) []notifier.SlackLink {
+	parsed := make([]notifier.SlackLink, 0, len(links))
+	for _, link := range links {
+		text, url, found := strings.Cut(link, "=")
+		if !found || text == "" {
+			log.WithField("link", link).Fatal("invalid slack link, expected Text=URL")
+		}
+		slackLink, err := notifier.NewSlackLink(text, url)
+		if err != nil {
+			log.WithField("err", err).Fatal("failed to parse slack link")
+		}
+		parsed = append(parsed, slackLink)
+	}
+	return parsed
+}
+
+func parseHeaders(headers []string) map[string]string {
+	parsed := make(map[string]string, len(headers))
+	for _, header := range headers {
+		key, value, found := strings.Cut(header, ":")
+		if !found || strings.TrimSpace(key) == "" {
+			log.WithField("header", header).Fatal("invalid header, expected 'Key: Value'")
+		}
+		parsed[strings.TrimSpace(key)] = strings.TrimSpace(value)
+	}
+	return parsed
+}
+
+func createHealthCheckers(client kubernetes.Interface, namespaces, namespaceLabels labels.Selector) []health.Checker {
+	checkers := []health.Checker{}
+	if maxNotReadyNodes >= 0 {
+		checkers = append(checkers, health.NewNodeChecker(client, maxNotReadyNodes))
+	}
+	if maxUnhealthyPods >= 0 {
+		checkers = append(checkers, health.NewPodChecker(client, namespaces, namespaceLabels, clientNamespaceScope, maxUnhealthyPods))
+	}
+	if maxUnavailable >= 0 {
+		checkers = append(checkers, health.NewReplicaChecker(client, namespaces, namespaceLabels, clientNamespaceScope, maxUnavailable))
+	}
+
+	log.WithFields(log.Fields{
+		"maxNotReadyNodes":       maxNotReadyNodes,
+		"maxUnhealthyPods":       maxUnhealthyPods,
+		"maxUnavailableReplicas": maxUnavailable,
+	}).Info("setting cluster health checks")
+
+	if prometheusAddress == "" && len(prometheusQueries) > 0 {
+		log.WithField("queries", prometheusQueries).Fatal("prometheus queries require a prometheus address")
+	}
+	if prometheusAddress != "" && len(prometheusQueries) > 0 {
+		checkers = append(checkers, health.NewPrometheusChecker(prometheusAddress, prometheusQueries))
+
+		log.WithFields(log.Fields{
+			"address": prometheusAddress,
+			"queries": prometheusQueries,
+		}).Info("setting prometheus health check")
+	}
+
+	return checkers
+}
+
+func createAuditSink(client kubernetes.Interface, history *audit.MemorySink) audit.Sink {
+	sinks := audit.Sinks{history}
+
+	if auditLog != "" {
+		sink, err := audit.NewFileSink(auditLog)
+		if err != nil {
+			log.WithFields(log.Fields{
+				"path": auditLog,
+				"err":  err,
+			}).Fatal("failed to open audit log")
+		}
+		sinks = append(sinks, sink)
+
+		log.WithField("path", auditLog).Info("writing audit log")
+	}
+
+	if auditConfigMap != "" {
+		namespace, name, found := strings.Cut(auditConfigMap, "/")
+		if !found {
+			log.WithField("auditConfigMap", auditConfigMap).Fatal("failed to parse audit configmap, expected namespace/name")
+		}
+		sinks = append(sinks, audit.NewConfigMapSink(client, namespace, name, auditConfigMapSize))
+
+		log.WithFields(log.Fields{
+			"configMap": auditConfigMap,
+			"size":      auditConfigMapSize,
+		}).Info("writing audit records to configmap")
+	}
+
+	return sinks
+}
+
+func printCandidates(chaoskube *chaoskube.Chaoskube) {
+	pods, err := chaoskube.Candidates(context.Background())
+	if err != nil {
+		log.WithField("err", err).Fatal("failed to list candidates")
+	}
+
+	if err := api.WritePods(os.Stdout, api.Summarize(pods, chaoskube.Now()), output); err != nil {
+		log.WithField("err", err).Fatal("failed to print candidates")
+	}
+}
+
+func printExplanations(chaoskube *chaoskube.Chaoskube) {
+	explanations, err := chaoskube.Explain(context.Background())
+	if err != nil {
+		log.WithField("err", err).Fatal("failed to explain candidates")
+	}
+
+	explanations, err = api.SelectExplanation(explanations, explainPod)
+	if err != nil {
+		log.WithField("err", err).Fatal("failed to explain pod")
+	}
+
+	if err := api.WriteExplanations(os.Stdout, explanations, output); err != nil {
+		log.WithField("err", err).Fatal("failed to print explanations")
+	}
+}
+
+func serveMetrics(chaoskube *chaoskube.Chaoskube, history *audit.MemorySink, nextRun func() time.Time) {
 	http.Handle("/metrics", promhttp.Handler())
 	http.HandleFunc("/healthz", func(w http.ResponseWriter, _ *http.Request) {
 		fmt.Fprintln(w, "OK")
 	})
-	http.HandleFunc("/", func(w http.ResponseWriter, _ *http.Request) {
-		fmt.Fprintln(w, adminPage)
-	})
+	// the pod inventory, the history and the configuration require the API token if one is configured.
+	protect := func(handler http.Handler) http.Handler { return handler }
+	if apiToken != "" {
+		server := api.NewServer(chaoskube, history, log.StandardLogger(), apiToken)
+		http.Handle("/api/", server.Handler())
+		protect = server.Authenticate
+	} else {
+		// without a token the rest of the API is disabled but the history is as public as the dashboard
+		http.Handle("GET /api/history", api.HistoryHandler(history))
+	}
+	http.Handle("/candidates", protect(api.CandidatesHandler(chaoskube)))
+	http.Handle("/debug/explain", protect(api.ExplainHandler(chaoskube)))
+	dashboard := api.NewDashboard(chaoskube, history, nextRun)
+	dashboard.HideConfig = apiToken != ""
+	http.Handle("/", dashboard.Handler())
 	if err := http.ListenAndServe(metricsAddress, nil); err != nil {
 		log.WithField("err", err).Fatal("failed to start HTTP server")
 	}
@@ -334,15 +854,3 @@ func prettifyCaller(f *runtime.Frame) (string, string) {
 	_, filename := path.Split(f.File)
 	return "", fmt.Sprintf("%s:%d", filename, f.Line)
 }
-
-var adminPage = `<html>
-	<head>
-		<title>chaoskube</title>
-	</head>
-	<body>
-		<h1>chaoskube</h1>
-		<p><a href="/metrics">Metrics</a></p>
-		<p><a href="/healthz">Health Check</a></p>
-		<p><a href="/debug/pprof">pprof</a></p>
-	</body>
-</html>`
@@ -0,0 +1,102 @@
+package metrics
+
+import (
+	"fmt"
+	"sync"
+)
+
+// Optional labels of the termination metrics.
+const (
+	LabelOwnerKind  = "owner_kind"
+	LabelOwnerName  = "owner_name"
+	LabelNode       = "node"
+	LabelTerminator = "terminator"
+)
+
+// OverflowValue replaces the values of an optional label once its cardinality limit is reached.
+const OverflowValue = "other"
+
+// optionalLabels lists the optional labels in the order they are attached to the termination metrics.
+var optionalLabels = []string{LabelOwnerKind, LabelOwnerName, LabelNode, LabelTerminator}
+
+// TerminationLabels decides which optional labels are attached to the termination metrics.
+// It guards against unbounded cardinality by reporting all values of a label beyond
+// the first MaxValues distinct ones as OverflowValue. A nil TerminationLabels leaves all
+// optional labels empty.
+type TerminationLabels struct {
+	enabled   map[string]bool
+	maxValues int
+
+	mu   sync.Mutex
+	seen map[string]map[string]struct{}
+}
+
+// NewTerminationLabels returns a TerminationLabels that attaches the given optional labels.
+// A maxValues of zero or less doesn't limit the number of distinct values.
+func NewTerminationLabels(labels []string, maxValues int) (*TerminationLabels, error) {
+	enabled := make(map[string]bool, len(labels))
+	for _, label := range labels {
+		if !isOptionalLabel(label) {
+			return nil, fmt.Errorf("unsupported metrics label: %s", label)
+		}
+		enabled[label] = true
+	}
+
+	return &TerminationLabels{
+		enabled:   enabled,
+		maxValues: maxValues,
+		seen:      make(map[string]map[string]struct{}),
+	}, nil
+}
+
+// Values returns the label values of a termination in the order namespace, owner kind,
+// owner name, node and terminator. Disabled labels are left empty.
+func (l *TerminationLabels) Values(namespace, ownerKind, ownerName, node, terminator string) []string {
+	values := []string{namespace, "", "", "", ""}
+	if l == nil {
+		return values
+	}
+
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	for i, value := range []string{ownerKind, ownerName, node, terminator} {
+		if label := optionalLabels[i]; l.enabled[label] {
+			values[i+1] = l.limit(label, value)
+		}
+	}
+
+	return values
+}
+
+// limit returns the value unless the label already has maxValues other distinct values.
+func (l *TerminationLabels) limit(label, value string) string {
+	if l.maxValues <= 0 || value == "" {
+		return value
+	}
+
+	seen, ok := l.seen[label]
+	if !ok {
+		seen = make(map[string]struct{})
+		l.seen[label] = seen
+	}
+
+	if _, ok := seen[value]; ok {
+		return value
+	}
+	if len(seen) >= l.maxValues {
+		return OverflowValue
+	}
+
+	seen[value] = struct{}{}
+	return value
+}
+
+func isOptionalLabel(label string) bool {
+	for _, l := range optionalLabels {
+		if l == label {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,44 @@
+package metrics
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+)
+
+type LabelsSuite struct {
+	suite.Suite
+}
+
+func (suite *LabelsSuite) TestValues() {
+	labels, err := NewTerminationLabels([]string{LabelOwnerName, LabelNode}, 0)
+	suite.Require().NoError(err)
+
+	suite.Equal([]string{"default", "", "foo", "node-1", ""}, labels.Values("default", "ReplicaSet", "foo", "node-1", "DeletePod"))
+}
+
+func (suite *LabelsSuite) TestValuesNil() {
+	var labels *TerminationLabels
+
+	suite.Equal([]string{"default", "", "", "", ""}, labels.Values("default", "ReplicaSet", "foo", "node-1", "DeletePod"))
+}
+
+func (suite *LabelsSuite) TestCardinalityGuard() {
+	labels, err := NewTerminationLabels([]string{LabelOwnerKind, LabelOwnerName}, 2)
+	suite.Require().NoError(err)
+
+	suite.Equal([]string{"default", "ReplicaSet", "foo", "", ""}, labels.Values("default", "ReplicaSet", "foo", "", ""))
+	suite.Equal([]string{"default", "ReplicaSet", "bar", "", ""}, labels.Values("default", "ReplicaSet", "bar", "", ""))
+	suite.Equal([]string{"default", "StatefulSet", OverflowValue, "", ""}, labels.Values("default", "StatefulSet", "baz", "", ""))
+	suite.Equal([]string{"default", "ReplicaSet", "foo", "", ""}, labels.Values("default", "ReplicaSet", "foo", "", ""))
+	suite.Equal([]string{"default", "", "", "", ""}, labels.Values("default", "", "", "", ""))
+}
+
+func (suite *LabelsSuite) TestUnsupportedLabel() {
+	_, err := NewTerminationLabels([]string{"pod"}, 0)
+	suite.EqualError(err, "unsupported metrics label: pod")
+}
+
+func TestLabelsSuite(t *testing.T) {
+	suite.Run(t, new(LabelsSuite))
+}
@@ -5,13 +5,39 @@ import (
 	"github.com/prometheus/client_golang/prometheus/promauto"
 )
 
+// Results of delivering a notification as used by NotificationsTotal.
+const (
+	NotificationDelivered = "delivered"
+	NotificationRetried   = "retried"
+	NotificationFailed    = "failed"
+	NotificationDropped   = "dropped"
+)
+
+// Reasons for skipping an interval as used by IntervalsSkippedTotal.
+const (
+	SkipReasonPaused      = "paused"
+	SkipReasonWeekday     = "weekday"
+	SkipReasonTimeOfDay   = "time_of_day"
+	SkipReasonDayOfYear   = "day_of_year"
+	SkipReasonHealthCheck = "health_check"
+	SkipReasonNoVictim    = "no_victim"
+	SkipReasonCancelled   = "cancelled"
+)
+
 var (
-	// PodsDeletedTotal is the total number of deleted pods.
+	// PodsDeletedTotal is the total number of deleted pods. The dry_run label tells the pods that
+	// would have been deleted in dry-run mode apart from the ones that were deleted for real.
 	PodsDeletedTotal = promauto.NewCounterVec(prometheus.CounterOpts{
 		Namespace: "chaoskube",
 		Name:      "pods_deleted_total",
-		Help:      "The total number of pods deleted",
-	}, []string{"namespace"})
+		Help:      "The total number of pods deleted, or that would have been deleted in dry-run mode",
+	}, append(append([]string{"namespace"}, optionalLabels...), "dry_run"))
+	// TerminationsFailedTotal is the total number of pods that couldn't be terminated.
+	TerminationsFailedTotal = promauto.NewCounterVec(prometheus.CounterOpts{
+		Namespace: "chaoskube",
+		Name:      "terminations_failed_total",
+		Help:      "The total number of failed pod terminations, by error class",
+	}, append(append([]string{"namespace"}, optionalLabels...), "reason"))
 	// IntervalsTotal is the total number of intervals, i.e. call to Run().
 	IntervalsTotal = promauto.NewCounter(prometheus.CounterOpts{
 		Namespace: "chaoskube",
@@ -30,4 +56,55 @@ var (
 		Name:      "termination_duration_seconds",
 		Help:      "The time it took a single pod termination to finish",
 	})
+	// HealthChecksFailedTotal is the total number of intervals skipped due to failing health checks.
+	HealthChecksFailedTotal = promauto.NewCounterVec(prometheus.CounterOpts{
+		Namespace: "chaoskube",
+		Name:      "health_checks_failed_total",
+		Help:      "The total number of intervals skipped due to failing health checks",
+	}, []string{"check"})
+	// NotificationsTotal is the total number of notification attempts by notifier and result.
+	NotificationsTotal = promauto.NewCounterVec(prometheus.CounterOpts{
+		Namespace: "chaoskube",
+		Name:      "notifications_total",
+		Help:      "The total number of notifications by notifier and result, i.e. delivered, retried, failed or dropped",
+	}, []string{"notifier", "result"})
+	// Paused indicates whether chaoskube is currently paused.
+	Paused = promauto.NewGauge(prometheus.GaugeOpts{
+		Namespace: "chaoskube",
+		Name:      "paused",
+		Help:      "Whether chaoskube is currently paused (1) or not (0)",
+	})
+	// Candidates is the number of pods remaining after each filter stage in the most recent run.
+	Candidates = promauto.NewGaugeVec(prometheus.GaugeOpts{
+		Namespace: "chaoskube",
+		Name:      "candidates",
+		Help:      "The number of pods remaining after each filter stage in the most recent run",
+	}, []string{"stage"})
+	// Victims is the number of victims selected in the most recent run.
+	Victims = promauto.NewGauge(prometheus.GaugeOpts{
+		Namespace: "chaoskube",
+		Name:      "victims",
+		Help:      "The number of victims selected in the most recent run",
+	})
+	// IntervalsSkippedTotal is the total number of intervals in which no pod was terminated.
+	IntervalsSkippedTotal = promauto.NewCounterVec(prometheus.CounterOpts{
+		Namespace: "chaoskube",
+		Name:      "intervals_skipped_total",
+		Help:      "The total number of intervals in which no pod was terminated, by reason",
+	}, []string{"reason"})
 )
+
+func init() {
+	// export all reasons right away so that rates can be computed before the first skip
+	for _, reason := range []string{
+		SkipReasonPaused,
+		SkipReasonWeekday,
+		SkipReasonTimeOfDay,
+		SkipReasonDayOfYear,
+		SkipReasonHealthCheck,
+		SkipReasonNoVictim,
+		SkipReasonCancelled,
+	} {
+		IntervalsSkippedTotal.WithLabelValues(reason)
+	}
+}
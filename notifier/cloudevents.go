@@ -0,0 +1,195 @@
+package notifier
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"time"
+
+	"github.com/linki/chaoskube/util"
+)
+
+const NotifierCloudEvents = "cloudevents"
+
+// The types of CloudEvents emitted by chaoskube, one per EventType.
+const (
+	cloudEventTypePrefix = "io.chaoskube."
+
+	CloudEventIntervalStarted   = cloudEventTypePrefix + string(EventIntervalStarted)
+	CloudEventIntervalSkipped   = cloudEventTypePrefix + string(EventIntervalSkipped)
+	CloudEventVictimsSelected   = cloudEventTypePrefix + string(EventVictimsSelected)
+	CloudEventPodTerminated     = cloudEventTypePrefix + string(EventPodTerminated)
+	CloudEventDryRunTermination = cloudEventTypePrefix + string(EventDryRunTermination)
+	CloudEventTerminationFailed = cloudEventTypePrefix + string(EventTerminationFailed)
+	CloudEventIntervalCompleted = cloudEventTypePrefix + string(EventIntervalCompleted)
+	CloudEventRecoveryCompleted = cloudEventTypePrefix + string(EventRecoveryCompleted)
+)
+
+// The content modes of the CloudEvents HTTP protocol binding.
+const (
+	CloudEventsModeStructured = "structured"
+	CloudEventsModeBinary     = "binary"
+)
+
+const (
+	cloudEventsSpecVersion         = "1.0"
+	cloudEventsStructuredMediaType = "application/cloudevents+json"
+)
+
+// DefaultCloudEventsSource is the source attribute of the emitted CloudEvents.
+var DefaultCloudEventsSource = "chaoskube"
+
+// CloudEvent is a CloudEvent in its JSON event format.
+type CloudEvent struct {
+	SpecVersion     string         `json:"specversion"`
+	ID              string         `json:"id"`
+	Source          string         `json:"source"`
+	Type            string         `json:"type"`
+	Subject         string         `json:"subject,omitempty"`
+	Time            time.Time      `json:"time"`
+	DataContentType string         `json:"datacontenttype"`
+	Data            CloudEventData `json:"data"`
+}
+
+// CloudEventData is the payload of all CloudEvents emitted by chaoskube.
+// The pod fields are set for pod events, Victims and Candidates for selected
+// victims, Reason for skipped intervals, Error for failed terminations and
+// RecoverySeconds for completed recoveries.
+type CloudEventData struct {
+	Namespace       string   `json:"namespace,omitempty"`
+	Name            string   `json:"name,omitempty"`
+	OwnerKind       string   `json:"ownerKind,omitempty"`
+	OwnerName       string   `json:"ownerName,omitempty"`
+	Node            string   `json:"node,omitempty"`
+	Victims         []string `json:"victims,omitempty"`
+	Candidates      int      `json:"candidates,omitempty"`
+	Reason          string   `json:"reason,omitempty"`
+	Error           string   `json:"error,omitempty"`
+	RecoverySeconds float64  `json:"recoverySeconds,omitempty"`
+	Experiment      string   `json:"experiment,omitempty"`
+	DryRun          bool     `json:"dryRun"`
+}
+
+// CloudEvents sends chaos actions as CloudEvents over HTTP, e.g. to a Knative broker.
+type CloudEvents struct {
+	Subscription
+	Sink       string
+	Source     string
+	Mode       string
+	Experiment string
+	Client     *http.Client
+}
+
+// NewCloudEventsNotifier returns a CloudEvents notifier sending events to sink in the given content mode.
+func NewCloudEventsNotifier(sink, mode, experiment string) (*CloudEvents, error) {
+	switch mode {
+	case "":
+		mode = CloudEventsModeStructured
+	case CloudEventsModeStructured, CloudEventsModeBinary:
+	default:
+		return nil, fmt.Errorf("unsupported cloudevents mode: %s", mode)
+	}
+
+	return &CloudEvents{
+		Subscription: Subscription{Events: []EventType{EventPodTerminated, EventDryRunTermination, EventTerminationFailed, EventIntervalSkipped, EventTerminationAnnounced, EventTerminationCancelled}},
+		Sink:         sink,
+		Source:       DefaultCloudEventsSource,
+		Mode:         mode,
+		Experiment:   experiment,
+		Client:       &http.Client{Timeout: DefaultTimeout},
+	}, nil
+}
+
+func (c CloudEvents) Notify(ctx context.Context, event Event) error {
+	return c.send(ctx, c.cloudEvent(event))
+}
+
+// cloudEvent converts the event into a CloudEvent.
+func (c CloudEvents) cloudEvent(event Event) CloudEvent {
+	ce := CloudEvent{
+		SpecVersion:     cloudEventsSpecVersion,
+		ID:              eventID(event),
+		Source:          c.Source,
+		Type:            cloudEventTypePrefix + string(event.Type),
+		Time:            eventTime(event),
+		DataContentType: "application/json",
+		Data: CloudEventData{
+			Candidates:      event.Candidates,
+			Reason:          event.Reason,
+			RecoverySeconds: event.Duration.Seconds(),
+			Experiment:      c.Experiment,
+			DryRun:          event.DryRun,
+		},
+	}
+	for _, victim := range event.Victims {
+		ce.Data.Victims = append(ce.Data.Victims, victim.Namespace+"/"+victim.Name)
+	}
+	if event.Error != nil {
+		ce.Data.Error = event.Error.Error()
+	}
+	if pod := event.Pod; pod != nil {
+		ce.Subject = pod.Namespace + "/" + pod.Name
+		ce.Data.Namespace = pod.Namespace
+		ce.Data.Name = pod.Name
+		ce.Data.Node = pod.Spec.NodeName
+		if owner := util.OwnerOf(*pod); owner != nil {
+			ce.Data.OwnerKind = owner.Kind
+			ce.Data.OwnerName = owner.Name
+		}
+	}
+	return ce
+}
+
+func (c CloudEvents) send(ctx context.Context, event CloudEvent) error {
+	var (
+		body []byte
+		err  error
+	)
+	if c.Mode == CloudEventsModeBinary {
+		body, err = json.Marshal(event.Data)
+	} else {
+		body, err = json.Marshal(event)
+	}
+	if err != nil {
+		return err
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, c.Sink, bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+
+	if c.Mode == CloudEventsModeBinary {
+		req.Header.Set("Content-Type", event.DataContentType)
+		req.Header.Set("ce-specversion", event.SpecVersion)
+		req.Header.Set("ce-id", event.ID)
+		req.Header.Set("ce-source", event.Source)
+		req.Header.Set("ce-type", event.Type)
+		req.Header.Set("ce-time", event.Time.Format(time.RFC3339Nano))
+		if event.Subject != "" {
+			req.Header.Set("ce-subject", event.Subject)
+		}
+	} else {
+		req.Header.Set("Content-Type", cloudEventsStructuredMediaType+"; charset=utf-8")
+	}
+
+	res, err := c.Client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer res.Body.Close()
+	_, _ = io.Copy(io.Discard, res.Body)
+
+	if res.StatusCode < 200 || res.StatusCode > 299 {
+		return fmt.Errorf("unexpected status code %d from cloudevents sink %s", res.StatusCode, c.Sink)
+	}
+
+	return nil
+}
+
+func (c CloudEvents) Name() string {
+	return NotifierCloudEvents
+}
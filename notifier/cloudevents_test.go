@@ -0,0 +1,158 @@
+package notifier
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	v1 "k8s.io/api/core/v1"
+
+	"github.com/linki/chaoskube/internal/testutil"
+	"github.com/linki/chaoskube/util"
+
+	"github.com/stretchr/testify/suite"
+)
+
+type CloudEventsSuite struct {
+	testutil.TestSuite
+}
+
+type cloudEventsRequest struct {
+	header http.Header
+	body   []byte
+}
+
+func (suite *CloudEventsSuite) newSink(status int) (*httptest.Server, *[]cloudEventsRequest) {
+	requests := []cloudEventsRequest{}
+	server := httptest.NewServer(http.HandlerFunc(func(res http.ResponseWriter, req *http.Request) {
+		body, err := io.ReadAll(req.Body)
+		suite.Require().NoError(err)
+		requests = append(requests, cloudEventsRequest{header: req.Header, body: body})
+		res.WriteHeader(status)
+	}))
+	return server, &requests
+}
+
+func (suite *CloudEventsSuite) newNotifier(sink, mode string) *CloudEvents {
+	notifier, err := NewCloudEventsNotifier(sink, mode, "game-day")
+	suite.Require().NoError(err)
+	return notifier
+}
+
+func (suite *CloudEventsSuite) TestStructuredMode() {
+	server, requests := suite.newSink(http.StatusAccepted)
+	defer server.Close()
+
+	pod := util.NewPodWithOwner("chaos", "foo-57df4db6b-h9ktj", v1.PodRunning, "uid")
+	pod.Spec.NodeName = "node-1"
+
+	notifier := suite.newNotifier(server.URL, "")
+	suite.True(notifier.Subscribes(EventPodTerminated))
+	suite.True(notifier.Subscribes(EventTerminationFailed))
+	suite.True(notifier.Subscribes(EventIntervalSkipped))
+	suite.False(notifier.Subscribes(EventIntervalStarted))
+
+	at := time.Date(2026, 1, 2, 3, 4, 5, 0, time.UTC)
+	suite.Require().NoError(notifier.Notify(context.Background(), Event{Type: EventPodTerminated, Time: at, Pod: &pod}))
+	suite.Require().NoError(notifier.Notify(context.Background(), Event{Type: EventTerminationFailed, Time: at, Pod: &pod, Error: errors.New("forbidden")}))
+	suite.Require().NoError(notifier.Notify(context.Background(), Event{Type: EventIntervalSkipped, Time: at, Reason: "weekday"}))
+	suite.Require().Len(*requests, 3)
+
+	for i, tt := range []struct {
+		eventType string
+		subject   string
+		data      CloudEventData
+	}{
+		{
+			CloudEventPodTerminated,
+			"chaos/foo-57df4db6b-h9ktj",
+			CloudEventData{Namespace: "chaos", Name: "foo-57df4db6b-h9ktj", OwnerKind: "testkind", Node: "node-1", Experiment: "game-day"},
+		},
+		{
+			CloudEventTerminationFailed,
+			"chaos/foo-57df4db6b-h9ktj",
+			CloudEventData{Namespace: "chaos", Name: "foo-57df4db6b-h9ktj", OwnerKind: "testkind", Node: "node-1", Error: "forbidden", Experiment: "game-day"},
+		},
+		{
+			CloudEventIntervalSkipped,
+			"",
+			CloudEventData{Reason: "weekday", Experiment: "game-day"},
+		},
+	} {
+		request := (*requests)[i]
+		suite.Equal("application/cloudevents+json; charset=utf-8", request.header.Get("Content-Type"))
+
+		var event CloudEvent
+		suite.Require().NoError(json.Unmarshal(request.body, &event))
+		suite.NotEmpty(event.ID)
+		suite.Equal("1.0", event.SpecVersion)
+		suite.Equal("chaoskube", event.Source)
+		suite.Equal(tt.eventType, event.Type)
+		suite.Equal(tt.subject, event.Subject)
+		suite.Equal(time.Date(2026, 1, 2, 3, 4, 5, 0, time.UTC), event.Time)
+		suite.Equal("application/json", event.DataContentType)
+		suite.Equal(tt.data, event.Data)
+	}
+
+	// every event has a unique id
+	var first, second CloudEvent
+	suite.Require().NoError(json.Unmarshal((*requests)[0].body, &first))
+	suite.Require().NoError(json.Unmarshal((*requests)[1].body, &second))
+	suite.NotEqual(first.ID, second.ID)
+
+	// retries of the same event keep its id
+	suite.Require().NoError(notifier.Notify(context.Background(), Event{Type: EventPodTerminated, Time: at, Pod: &pod}))
+	var retried CloudEvent
+	suite.Require().NoError(json.Unmarshal((*requests)[3].body, &retried))
+	suite.Equal(first.ID, retried.ID)
+
+	// an assigned id is used as is
+	suite.Require().NoError(notifier.Notify(context.Background(), Event{ID: "7b1c", Type: EventPodTerminated, Time: at, Pod: &pod}))
+	var assigned CloudEvent
+	suite.Require().NoError(json.Unmarshal((*requests)[4].body, &assigned))
+	suite.Equal("7b1c", assigned.ID)
+}
+
+func (suite *CloudEventsSuite) TestBinaryMode() {
+	server, requests := suite.newSink(http.StatusOK)
+	defer server.Close()
+
+	notifier := suite.newNotifier(server.URL, CloudEventsModeBinary)
+	pod := util.NewPod("chaos", "foo", v1.PodRunning)
+	at := time.Date(2026, 1, 2, 3, 4, 5, 0, time.UTC)
+	suite.Require().NoError(notifier.Notify(context.Background(), Event{Type: EventDryRunTermination, Time: at, Pod: &pod, DryRun: true}))
+	suite.Require().Len(*requests, 1)
+
+	request := (*requests)[0]
+	suite.Equal("application/json", request.header.Get("Content-Type"))
+	suite.Equal("1.0", request.header.Get("ce-specversion"))
+	suite.NotEmpty(request.header.Get("ce-id"))
+	suite.Equal("chaoskube", request.header.Get("ce-source"))
+	suite.Equal(CloudEventDryRunTermination, request.header.Get("ce-type"))
+	suite.Equal("chaos/foo", request.header.Get("ce-subject"))
+	suite.Equal("2026-01-02T03:04:05Z", request.header.Get("ce-time"))
+	suite.JSONEq(`{"namespace": "chaos", "name": "foo", "experiment": "game-day", "dryRun": true}`, string(request.body))
+}
+
+func (suite *CloudEventsSuite) TestUnexpectedStatus() {
+	server, _ := suite.newSink(http.StatusInternalServerError)
+	defer server.Close()
+
+	notifier := suite.newNotifier(server.URL, CloudEventsModeStructured)
+	err := notifier.Notify(context.Background(), Event{Type: EventIntervalSkipped, Reason: "paused"})
+	suite.EqualError(err, "unexpected status code 500 from cloudevents sink "+server.URL)
+}
+
+func (suite *CloudEventsSuite) TestInvalidMode() {
+	_, err := NewCloudEventsNotifier("http://example.com", "batch", "")
+	suite.EqualError(err, "unsupported cloudevents mode: batch")
+}
+
+func TestCloudEventsSuite(t *testing.T) {
+	suite.Run(t, new(CloudEventsSuite))
+}
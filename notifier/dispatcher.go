@@ -0,0 +1,313 @@
+package notifier
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"sync"
+	"time"
+
+	log "github.com/sirupsen/logrus"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/trace"
+
+	"github.com/linki/chaoskube/metrics"
+)
+
+// tracerName identifies the spans created by the Dispatcher.
+const tracerName = "github.com/linki/chaoskube/notifier"
+
+// Attributes attached to the spans of each delivery.
+const (
+	attributeNotifier = attribute.Key("chaoskube.notifier")
+	attributeEvent    = attribute.Key("chaoskube.event")
+	attributeAttempt  = attribute.Key("chaoskube.attempt")
+)
+
+// Defaults of the Dispatcher created by NewDispatcher.
+const (
+	DefaultBufferSize     = 100
+	DefaultMaxRetries     = 5
+	DefaultInitialBackoff = time.Second
+	DefaultMaxBackoff     = time.Minute
+)
+
+// DefaultRateLimit is the minimum time between two notifications of the same notifier
+// unless configured otherwise.
+const DefaultRateLimit = time.Second
+
+// Dispatcher delivers events to its notifiers in the background so that slow or failing
+// notifiers don't hold up the chaos loop. Each notifier gets its own bounded queue, is
+// retried with exponential backoff and can be rate limited. Events that don't fit into a
+// full queue are dropped. Call Close to flush the queues on shutdown.
+type Dispatcher struct {
+	// BufferSize is the number of events queued per notifier before new events are dropped.
+	BufferSize int
+	// MaxRetries is how often a failed delivery is retried before the event is given up.
+	MaxRetries int
+	// InitialBackoff is the wait before the first retry, it doubles with every further retry.
+	InitialBackoff time.Duration
+	// MaxBackoff caps the wait between two retries.
+	MaxBackoff time.Duration
+	// Logger to report dropped and failed notifications to.
+	Logger log.FieldLogger
+	// Tracer records a span for every delivery attempt, the global tracer if nil.
+	Tracer trace.Tracer
+
+	mu     sync.RWMutex
+	queues []*queue
+	closed bool
+	wg     sync.WaitGroup
+	abort  chan struct{}
+	once   sync.Once
+}
+
+// queue holds the pending events of a single notifier.
+type queue struct {
+	notifier    Notifier
+	name        string
+	minInterval time.Duration
+	last        time.Time
+	events      chan delivery
+}
+
+// part is an event, or the part of it, that a single notifier receives.
+type part struct {
+	notifier Notifier
+	event    Event
+}
+
+// splitter is implemented by notifiers that send an event to several destinations, like the
+// Router. The Dispatcher delivers and retries each part on its own, so that a failing
+// destination doesn't cause the others to be notified again.
+type splitter interface {
+	split(ctx context.Context, event Event) ([]part, error)
+}
+
+// delivery is an event waiting to be sent along with the context it was raised in.
+type delivery struct {
+	ctx   context.Context
+	event Event
+}
+
+// NewDispatcher returns a Dispatcher with the default buffer size and retry settings.
+func NewDispatcher(logger log.FieldLogger) *Dispatcher {
+	return &Dispatcher{
+		BufferSize:     DefaultBufferSize,
+		MaxRetries:     DefaultMaxRetries,
+		InitialBackoff: DefaultInitialBackoff,
+		MaxBackoff:     DefaultMaxBackoff,
+		Logger:         logger,
+		abort:          make(chan struct{}),
+	}
+}
+
+// Add registers a notifier and starts delivering its events. Deliveries to the notifier
+// are at least minInterval apart, zero disables rate limiting.
+func (d *Dispatcher) Add(notifier Notifier, minInterval time.Duration) {
+	q := &queue{
+		notifier:    notifier,
+		name:        Name(notifier),
+		minInterval: minInterval,
+		events:      make(chan delivery, d.BufferSize),
+	}
+
+	d.mu.Lock()
+	d.queues = append(d.queues, q)
+	d.mu.Unlock()
+
+	d.wg.Add(1)
+	go func() {
+		defer d.wg.Done()
+		for delivery := range q.events {
+			d.deliver(q, delivery)
+		}
+	}()
+}
+
+// Subscribes returns whether any of the notifiers subscribes to eventType.
+func (d *Dispatcher) Subscribes(eventType EventType) bool {
+	d.mu.RLock()
+	defer d.mu.RUnlock()
+	for _, q := range d.queues {
+		if q.notifier.Subscribes(eventType) {
+			return true
+		}
+	}
+	return false
+}
+
+// Notify queues the event for all notifiers that subscribe to its type and returns right away.
+// It returns an error for every notifier whose queue is full or that was already closed.
+func (d *Dispatcher) Notify(ctx context.Context, event Event) error {
+	d.mu.RLock()
+	defer d.mu.RUnlock()
+
+	var dropped []string
+	for _, q := range d.queues {
+		if !q.notifier.Subscribes(event.Type) {
+			continue
+		}
+		if !d.closed {
+			select {
+			case q.events <- delivery{ctx: context.WithoutCancel(ctx), event: event}:
+				continue
+			default:
+			}
+		}
+		metrics.NotificationsTotal.WithLabelValues(q.name, metrics.NotificationDropped).Inc()
+		dropped = append(dropped, q.name)
+	}
+
+	if len(dropped) > 0 {
+		return fmt.Errorf("dropped %s event for notifiers: %s", event.Type, strings.Join(dropped, ", "))
+	}
+	return nil
+}
+
+// Close stops accepting events and waits until the queued events are delivered. If ctx
+// expires first, pending retries are abandoned and the remaining events are dropped.
+func (d *Dispatcher) Close(ctx context.Context) error {
+	d.mu.Lock()
+	if !d.closed {
+		d.closed = true
+		for _, q := range d.queues {
+			close(q.events)
+		}
+	}
+	d.mu.Unlock()
+
+	flushed := make(chan struct{})
+	go func() {
+		d.wg.Wait()
+		close(flushed)
+	}()
+
+	select {
+	case <-flushed:
+		return nil
+	case <-ctx.Done():
+		d.once.Do(func() { close(d.abort) })
+		<-flushed
+		return fmt.Errorf("failed to flush notifications: %w", ctx.Err())
+	}
+}
+
+// deliver sends the event to the queue's notifier. Notifiers with several destinations
+// get each part of the event delivered separately.
+func (d *Dispatcher) deliver(q *queue, delivery delivery) {
+	parts := []part{{notifier: q.notifier, event: delivery.event}}
+	if splitter, ok := q.notifier.(splitter); ok {
+		var err error
+		if parts, err = splitter.split(delivery.ctx, delivery.event); err != nil {
+			d.Logger.WithFields(log.Fields{
+				"notifier": q.name,
+				"event":    delivery.event.Type,
+				"err":      err,
+			}).Warn("failed to route notification")
+		}
+	}
+
+	for _, part := range parts {
+		d.deliverPart(delivery.ctx, q, part)
+	}
+}
+
+// deliverPart sends a part of an event, retrying with exponential backoff.
+func (d *Dispatcher) deliverPart(ctx context.Context, q *queue, part part) {
+	logger := d.Logger.WithFields(log.Fields{
+		"notifier": q.name,
+		"event":    part.event.Type,
+	})
+
+	backoff := d.InitialBackoff
+	for attempt := 0; ; attempt++ {
+		if !d.wait(time.Until(q.last.Add(q.minInterval))) {
+			metrics.NotificationsTotal.WithLabelValues(q.name, metrics.NotificationDropped).Inc()
+			logger.Warn("dropped notification on shutdown")
+			return
+		}
+		q.last = time.Now()
+
+		err := d.notify(ctx, q.name, part, attempt)
+		if err == nil {
+			metrics.NotificationsTotal.WithLabelValues(q.name, metrics.NotificationDelivered).Inc()
+			return
+		}
+
+		if attempt >= d.MaxRetries {
+			metrics.NotificationsTotal.WithLabelValues(q.name, metrics.NotificationFailed).Inc()
+			logger.WithField("err", err).Warn("failed to notify")
+			return
+		}
+
+		metrics.NotificationsTotal.WithLabelValues(q.name, metrics.NotificationRetried).Inc()
+		logger.WithFields(log.Fields{
+			"err":     err,
+			"backoff": backoff,
+		}).Debug("retrying notification")
+
+		if !d.wait(backoff) {
+			metrics.NotificationsTotal.WithLabelValues(q.name, metrics.NotificationDropped).Inc()
+			logger.Warn("dropped notification on shutdown")
+			return
+		}
+		backoff = min(2*backoff, d.MaxBackoff)
+	}
+}
+
+// notify sends the part to its notifier in a span that's a child of the span the event
+// was raised in, so that each notifier's latency and errors show up in the trace.
+func (d *Dispatcher) notify(ctx context.Context, name string, part part, attempt int) error {
+	tracer := d.Tracer
+	if tracer == nil {
+		tracer = otel.Tracer(tracerName)
+	}
+
+	ctx, span := tracer.Start(ctx, "Deliver "+name, trace.WithAttributes(
+		attributeNotifier.String(name),
+		attributeEvent.String(string(part.event.Type)),
+		attributeAttempt.Int(attempt),
+	))
+	defer span.End()
+
+	err := part.notifier.Notify(ctx, part.event)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	return err
+}
+
+// wait blocks for the given duration and returns false if the dispatcher was aborted meanwhile.
+func (d *Dispatcher) wait(duration time.Duration) bool {
+	if duration <= 0 {
+		select {
+		case <-d.abort:
+			return false
+		default:
+			return true
+		}
+	}
+
+	timer := time.NewTimer(duration)
+	defer timer.Stop()
+
+	select {
+	case <-d.abort:
+		return false
+	case <-timer.C:
+		return true
+	}
+}
+
+// Name returns the name of the given notifier, e.g. to label metrics. Notifiers can
+// report their name by implementing a Name method, otherwise the type name is used.
+func Name(n Notifier) string {
+	if named, ok := n.(interface{ Name() string }); ok {
+		return named.Name()
+	}
+	return strings.TrimPrefix(fmt.Sprintf("%T", n), "*")
+}
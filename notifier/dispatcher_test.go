@@ -0,0 +1,212 @@
+package notifier
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+	"time"
+
+	promtest "github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/sirupsen/logrus/hooks/test"
+	"go.opentelemetry.io/otel/codes"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+
+	"github.com/linki/chaoskube/internal/testutil"
+	"github.com/linki/chaoskube/metrics"
+
+	"github.com/stretchr/testify/suite"
+)
+
+type DispatcherSuite struct {
+	testutil.TestSuite
+}
+
+func (suite *DispatcherSuite) SetupTest() {
+	metrics.NotificationsTotal.Reset()
+}
+
+// flaky fails the first failures calls and optionally blocks until released.
+type flaky struct {
+	Subscription
+	name     string
+	failures int
+	release  chan struct{}
+
+	mu    sync.Mutex
+	calls []time.Time
+}
+
+func (f *flaky) Notify(ctx context.Context, event Event) error {
+	if f.release != nil {
+		<-f.release
+	}
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.calls = append(f.calls, time.Now())
+	if len(f.calls) <= f.failures {
+		return errors.New("unavailable")
+	}
+	return nil
+}
+
+func (f *flaky) Name() string {
+	return f.name
+}
+
+func (f *flaky) Calls() []time.Time {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	return append([]time.Time(nil), f.calls...)
+}
+
+func (suite *DispatcherSuite) newDispatcher() *Dispatcher {
+	logger, _ := test.NewNullLogger()
+	dispatcher := NewDispatcher(logger)
+	dispatcher.InitialBackoff = time.Millisecond
+	dispatcher.MaxBackoff = 4 * time.Millisecond
+	return dispatcher
+}
+
+func (suite *DispatcherSuite) count(name, result string) float64 {
+	return promtest.ToFloat64(metrics.NotificationsTotal.WithLabelValues(name, result))
+}
+
+func (suite *DispatcherSuite) TestDeliversInBackground() {
+	dispatcher := suite.newDispatcher()
+	subscribed := &Noop{}
+	unsubscribed := &Noop{Subscription: Subscription{Events: []EventType{EventPodTerminated}}}
+	dispatcher.Add(subscribed, 0)
+	dispatcher.Add(unsubscribed, 0)
+
+	suite.True(dispatcher.Subscribes(EventIntervalSkipped))
+	suite.Require().NoError(dispatcher.Notify(context.Background(), Event{Type: EventIntervalSkipped}))
+	suite.Require().NoError(dispatcher.Close(context.Background()))
+
+	suite.Equal([]EventType{EventIntervalSkipped}, subscribed.Received())
+	suite.Empty(unsubscribed.Received())
+}
+
+func (suite *DispatcherSuite) TestRetriesWithBackoff() {
+	for _, tt := range []struct {
+		name       string
+		failures   int
+		maxRetries int
+		calls      int
+		delivered  float64
+		retried    float64
+		failed     float64
+	}{
+		{"retry-succeeds", 2, 3, 3, 1, 2, 0},
+		{"retry-exhausted", 5, 2, 3, 0, 2, 1},
+		{"no-retries", 1, 0, 1, 0, 0, 1},
+	} {
+		dispatcher := suite.newDispatcher()
+		dispatcher.MaxRetries = tt.maxRetries
+		notifier := &flaky{name: tt.name, failures: tt.failures}
+		dispatcher.Add(notifier, 0)
+
+		suite.Require().NoError(dispatcher.Notify(context.Background(), Event{Type: EventPodTerminated}))
+		suite.Require().NoError(dispatcher.Close(context.Background()))
+
+		suite.Len(notifier.Calls(), tt.calls, tt.name)
+		suite.Equal(tt.delivered, suite.count(tt.name, metrics.NotificationDelivered), tt.name)
+		suite.Equal(tt.retried, suite.count(tt.name, metrics.NotificationRetried), tt.name)
+		suite.Equal(tt.failed, suite.count(tt.name, metrics.NotificationFailed), tt.name)
+	}
+}
+
+func (suite *DispatcherSuite) TestTracesEachAttempt() {
+	recorder := tracetest.NewSpanRecorder()
+	tracer := sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(recorder)).Tracer("test")
+
+	dispatcher := suite.newDispatcher()
+	dispatcher.Tracer = tracer
+	dispatcher.Add(&flaky{name: "traced", failures: 1}, 0)
+
+	ctx, parent := tracer.Start(context.Background(), "Notify")
+	suite.Require().NoError(dispatcher.Notify(ctx, Event{Type: EventPodTerminated}))
+	parent.End()
+	suite.Require().NoError(dispatcher.Close(context.Background()))
+
+	spans := recorder.Ended()
+	suite.Require().Len(spans, 3)
+	for i, span := range spans[1:] {
+		suite.Equal("Deliver traced", span.Name())
+		suite.Equal(parent.SpanContext().SpanID(), span.Parent().SpanID())
+		suite.Contains(span.Attributes(), attributeAttempt.Int(i))
+	}
+	suite.Equal(codes.Error, spans[1].Status().Code)
+	suite.Equal(codes.Unset, spans[2].Status().Code)
+}
+
+func (suite *DispatcherSuite) TestDropsWhenFull() {
+	dispatcher := suite.newDispatcher()
+	dispatcher.BufferSize = 1
+	notifier := &flaky{name: "full", release: make(chan struct{})}
+	dispatcher.Add(notifier, 0)
+
+	// the first event is picked up by the blocked worker, the second one fills the buffer
+	suite.Require().NoError(dispatcher.Notify(context.Background(), Event{Type: EventPodTerminated}))
+	suite.Eventually(func() bool { return len(dispatcher.queues[0].events) == 0 }, time.Second, time.Millisecond)
+	suite.Require().NoError(dispatcher.Notify(context.Background(), Event{Type: EventPodTerminated}))
+
+	err := dispatcher.Notify(context.Background(), Event{Type: EventPodTerminated})
+	suite.EqualError(err, "dropped pod.terminated event for notifiers: full")
+	suite.Equal(1.0, suite.count("full", metrics.NotificationDropped))
+
+	close(notifier.release)
+	suite.Require().NoError(dispatcher.Close(context.Background()))
+	suite.Len(notifier.Calls(), 2)
+
+	err = dispatcher.Notify(context.Background(), Event{Type: EventPodTerminated})
+	suite.EqualError(err, "dropped pod.terminated event for notifiers: full")
+	suite.Equal(2.0, suite.count("full", metrics.NotificationDropped))
+}
+
+func (suite *DispatcherSuite) TestRateLimit() {
+	dispatcher := suite.newDispatcher()
+	notifier := &flaky{name: "limited"}
+	dispatcher.Add(notifier, 20*time.Millisecond)
+
+	for range 3 {
+		suite.Require().NoError(dispatcher.Notify(context.Background(), Event{Type: EventPodTerminated}))
+	}
+	suite.Require().NoError(dispatcher.Close(context.Background()))
+
+	calls := notifier.Calls()
+	suite.Require().Len(calls, 3)
+	for i := 1; i < len(calls); i++ {
+		suite.GreaterOrEqual(calls[i].Sub(calls[i-1]), 20*time.Millisecond)
+	}
+}
+
+func (suite *DispatcherSuite) TestCloseAbortsOnTimeout() {
+	dispatcher := suite.newDispatcher()
+	dispatcher.InitialBackoff = time.Hour
+	dispatcher.MaxBackoff = time.Hour
+	notifier := &flaky{name: "aborted", failures: 1}
+	dispatcher.Add(notifier, 0)
+
+	suite.Require().NoError(dispatcher.Notify(context.Background(), Event{Type: EventPodTerminated}))
+
+	ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+	defer cancel()
+	err := dispatcher.Close(ctx)
+	suite.ErrorIs(err, context.DeadlineExceeded)
+
+	suite.Len(notifier.Calls(), 1)
+	suite.Equal(1.0, suite.count("aborted", metrics.NotificationDropped))
+	suite.NoError(dispatcher.Close(context.Background()))
+}
+
+func (suite *DispatcherSuite) TestName() {
+	suite.Equal("noop", Name(&Noop{}))
+	suite.Equal("slack", Name(NewSlackNotifier("http://example.com")))
+	suite.Equal("notifier.Notifiers", Name(New()))
+}
+
+func TestDispatcherSuite(t *testing.T) {
+	suite.Run(t, new(DispatcherSuite))
+}
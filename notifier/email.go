@@ -0,0 +1,312 @@
+package notifier
+
+import (
+	"bytes"
+	"context"
+	"crypto/tls"
+	"errors"
+	"fmt"
+	htmltemplate "html/template"
+	"mime"
+	"mime/multipart"
+	"mime/quotedprintable"
+	"net"
+	"net/smtp"
+	"net/textproto"
+	"slices"
+	"strings"
+	"time"
+
+	log "github.com/sirupsen/logrus"
+
+	"github.com/linki/chaoskube/util"
+)
+
+const NotifierEmail = "email"
+
+// AnnotationEmailRecipients lists additional, comma separated recipients on a namespace
+// that receive the emails about pods in that namespace.
+const AnnotationEmailRecipients = "chaoskube.io/email-recipients"
+
+// Email sends plain text and HTML summaries of chaos events via SMTP.
+type Email struct {
+	Subscription
+	// Address of the SMTP server in the form host:port.
+	Address string
+	// Username and Password authenticate with PLAIN auth if a username is given.
+	Username string
+	Password string
+	From     string
+	To       []string
+	// StartTLS requires the connection to be upgraded with STARTTLS before authenticating.
+	StartTLS   bool
+	TLSConfig  *tls.Config
+	Experiment string
+	// Namespaces looks up the recipients annotated on the pods' namespaces, nil disables the lookup.
+	Namespaces *util.NamespaceCache
+	Timeout    time.Duration
+	// Logger to report namespaces whose recipients couldn't be looked up to.
+	Logger log.FieldLogger
+}
+
+// NewEmailNotifier returns an Email sending to the given recipients via the SMTP server at address.
+func NewEmailNotifier(address, from string, to []string, username, password string, namespaces *util.NamespaceCache, experiment string) *Email {
+	host, _, _ := net.SplitHostPort(address)
+	return &Email{
+		Subscription: Subscription{Events: DefaultEvents},
+		Address:      address,
+		Username:     username,
+		Password:     password,
+		From:         from,
+		To:           to,
+		StartTLS:     true,
+		TLSConfig:    &tls.Config{ServerName: host},
+		Experiment:   experiment,
+		Namespaces:   namespaces,
+		Timeout:      DefaultTimeout,
+		Logger:       log.StandardLogger(),
+	}
+}
+
+// Notify sends the event to the global recipients and those annotated on the event's
+// namespaces. Namespaces whose recipients can't be looked up are logged and skipped so
+// that the global recipients still get notified.
+func (e Email) Notify(ctx context.Context, event Event) error {
+	recipients := append([]string{}, e.To...)
+	for _, namespace := range eventNamespaces(event) {
+		annotated, err := e.namespaceRecipients(ctx, namespace)
+		if err != nil {
+			e.Logger.WithField("err", err).Warn("failed to look up email recipients")
+			continue
+		}
+		recipients = append(recipients, annotated...)
+	}
+	slices.Sort(recipients)
+	recipients = slices.Compact(recipients)
+
+	if len(recipients) == 0 {
+		return nil
+	}
+
+	message, err := e.message(event, recipients)
+	if err != nil {
+		return err
+	}
+	return e.send(ctx, recipients, message)
+}
+
+// namespaceRecipients returns the recipients annotated on the given namespace.
+func (e Email) namespaceRecipients(ctx context.Context, name string) ([]string, error) {
+	if e.Namespaces == nil {
+		return nil, nil
+	}
+	namespace, err := e.Namespaces.Get(ctx, name)
+	if err != nil {
+		return nil, fmt.Errorf("failed to look up email recipients of namespace %s: %w", name, err)
+	}
+	return splitList(namespace.Annotations[AnnotationEmailRecipients]), nil
+}
+
+// message renders the event as a multipart email with a plain text and an HTML part.
+func (e Email) message(event Event, recipients []string) ([]byte, error) {
+	title, text := describe(event)
+	fields := e.fields(event)
+
+	var body bytes.Buffer
+	parts := multipart.NewWriter(&body)
+
+	var plain strings.Builder
+	plain.WriteString(text + "\n")
+	if len(fields) > 0 {
+		plain.WriteString("\n")
+	}
+	for _, field := range fields {
+		fmt.Fprintf(&plain, "%s: %s\n", field[0], field[1])
+	}
+	if err := writePart(parts, "text/plain; charset=utf-8", plain.String()); err != nil {
+		return nil, err
+	}
+
+	var html bytes.Buffer
+	if err := emailTemplate.Execute(&html, map[string]interface{}{"Title": title, "Text": text, "Fields": fields}); err != nil {
+		return nil, fmt.Errorf("failed to render email: %w", err)
+	}
+	if err := writePart(parts, "text/html; charset=utf-8", html.String()); err != nil {
+		return nil, err
+	}
+	if err := parts.Close(); err != nil {
+		return nil, err
+	}
+
+	var message bytes.Buffer
+	fmt.Fprintf(&message, "From: %s\r\n", e.From)
+	fmt.Fprintf(&message, "To: %s\r\n", strings.Join(recipients, ", "))
+	fmt.Fprintf(&message, "Subject: %s\r\n", mime.QEncoding.Encode("utf-8", title))
+	fmt.Fprintf(&message, "Date: %s\r\n", eventTime(event).Format(time.RFC1123Z))
+	fmt.Fprintf(&message, "Message-ID: %s\r\n", e.messageID(event))
+	fmt.Fprintf(&message, "MIME-Version: 1.0\r\n")
+	fmt.Fprintf(&message, "Content-Type: multipart/alternative; boundary=%s\r\n\r\n", parts.Boundary())
+	message.Write(body.Bytes())
+
+	return message.Bytes(), nil
+}
+
+// messageID returns the Message-ID header value of the event in the domain of the sender
+// address. It's derived from the event's ID, so that retries can be recognized as duplicates.
+func (e Email) messageID(event Event) string {
+	domain := "localhost"
+	if at := strings.LastIndex(e.From, "@"); at >= 0 {
+		domain = strings.TrimSuffix(e.From[at+1:], ">")
+	}
+	return fmt.Sprintf("<%s@%s>", eventID(event), domain)
+}
+
+// fields returns the details of the event's pod as title and value pairs.
+func (e Email) fields(event Event) [][2]string {
+	fields := podFields(event)
+	if pod := event.Pod; pod != nil {
+		if owner := util.OwnerOf(*pod); owner != nil {
+			fields = append(fields, [2]string{"owner", owner.Kind + "/" + owner.Name})
+		}
+		if pod.Spec.NodeName != "" {
+			fields = append(fields, [2]string{"node", pod.Spec.NodeName})
+		}
+	}
+	if e.Experiment != "" {
+		fields = append(fields, [2]string{"experiment", e.Experiment})
+	}
+	return fields
+}
+
+// send delivers the message to the recipients, upgrading the connection with STARTTLS if configured.
+// Rejected recipients other than the global ones are logged and skipped, it only fails if a global
+// recipient or all of them are rejected.
+func (e Email) send(ctx context.Context, recipients []string, message []byte) error {
+	dialer := net.Dialer{Timeout: e.Timeout}
+	conn, err := dialer.DialContext(ctx, "tcp", e.Address)
+	if err != nil {
+		return err
+	}
+	deadline := time.Now().Add(e.Timeout)
+	if d, ok := ctx.Deadline(); ok && d.Before(deadline) {
+		deadline = d
+	}
+	if err := conn.SetDeadline(deadline); err != nil {
+		conn.Close()
+		return err
+	}
+
+	host, _, _ := net.SplitHostPort(e.Address)
+	client, err := smtp.NewClient(conn, host)
+	if err != nil {
+		conn.Close()
+		return err
+	}
+	defer client.Close()
+
+	if e.StartTLS {
+		if ok, _ := client.Extension("STARTTLS"); !ok {
+			return errors.New("smtp server doesn't support STARTTLS")
+		}
+		if err := client.StartTLS(e.TLSConfig); err != nil {
+			return err
+		}
+	}
+	if e.Username != "" {
+		if err := client.Auth(smtp.PlainAuth("", e.Username, e.Password, host)); err != nil {
+			return err
+		}
+	}
+
+	if err := client.Mail(e.From); err != nil {
+		return err
+	}
+	accepted := 0
+	for _, recipient := range recipients {
+		if err := client.Rcpt(recipient); err != nil {
+			if slices.Contains(e.To, recipient) {
+				return err
+			}
+			// a typo in a namespace's annotation shouldn't keep everyone else from being notified
+			e.Logger.WithFields(log.Fields{"recipient": recipient, "err": err}).Warn("email recipient rejected")
+			continue
+		}
+		accepted++
+	}
+	if accepted == 0 {
+		return errors.New("smtp server rejected all recipients")
+	}
+	data, err := client.Data()
+	if err != nil {
+		return err
+	}
+	if _, err := data.Write(message); err != nil {
+		return err
+	}
+	if err := data.Close(); err != nil {
+		return err
+	}
+	return client.Quit()
+}
+
+func (e Email) Name() string {
+	return NotifierEmail
+}
+
+var emailTemplate = htmltemplate.Must(htmltemplate.New(NotifierEmail).Parse(`<html>
+<body>
+<h2>{{ .Title }}</h2>
+<p>{{ .Text }}</p>
+{{- if .Fields }}
+<table>
+{{- range .Fields }}
+<tr><th align="left">{{ index . 0 }}</th><td>{{ index . 1 }}</td></tr>
+{{- end }}
+</table>
+{{- end }}
+</body>
+</html>
+`))
+
+// writePart adds a quoted-printable encoded part with the given content type.
+func writePart(parts *multipart.Writer, contentType, content string) error {
+	part, err := parts.CreatePart(textproto.MIMEHeader{
+		"Content-Type":              {contentType},
+		"Content-Transfer-Encoding": {"quoted-printable"},
+	})
+	if err != nil {
+		return err
+	}
+	encoder := quotedprintable.NewWriter(part)
+	if _, err := encoder.Write([]byte(content)); err != nil {
+		return err
+	}
+	return encoder.Close()
+}
+
+// eventNamespaces returns the namespaces of the pods the event is about.
+func eventNamespaces(event Event) []string {
+	var namespaces []string
+	if event.Pod != nil {
+		namespaces = append(namespaces, event.Pod.Namespace)
+	}
+	for _, victim := range event.Victims {
+		namespaces = append(namespaces, victim.Namespace)
+	}
+	for _, result := range event.Results {
+		namespaces = append(namespaces, result.Pod.Namespace)
+	}
+	slices.Sort(namespaces)
+	return slices.Compact(namespaces)
+}
+
+// splitList splits a comma separated list and drops empty entries.
+func splitList(list string) []string {
+	var items []string
+	for _, item := range strings.Split(list, ",") {
+		if item = strings.TrimSpace(item); item != "" {
+			items = append(items, item)
+		}
+	}
+	return items
+}
@@ -0,0 +1,334 @@
+package notifier
+
+import (
+	"bufio"
+	"context"
+	"crypto/tls"
+	"encoding/base64"
+	"io"
+	"mime"
+	"mime/multipart"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"net/mail"
+	"slices"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	log "github.com/sirupsen/logrus"
+	"github.com/sirupsen/logrus/hooks/test"
+
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes/fake"
+
+	"github.com/linki/chaoskube/internal/testutil"
+	"github.com/linki/chaoskube/util"
+
+	"github.com/stretchr/testify/suite"
+)
+
+type EmailSuite struct {
+	testutil.TestSuite
+}
+
+// smtpServer is a minimal SMTP stand-in that records the messages it receives.
+type smtpServer struct {
+	listener net.Listener
+	tls      *tls.Config
+	// reject lists the recipients the server refuses
+	reject []string
+
+	mu       sync.Mutex
+	auth     string
+	upgraded bool
+	from     string
+	rcpt     []string
+	data     string
+}
+
+func newSMTPServer(tlsConfig *tls.Config) *smtpServer {
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		panic(err)
+	}
+	server := &smtpServer{listener: listener, tls: tlsConfig}
+	go server.serve()
+	return server
+}
+
+func (s *smtpServer) Address() string {
+	return s.listener.Addr().String()
+}
+
+func (s *smtpServer) Close() {
+	s.listener.Close()
+}
+
+// received returns a copy of the recorded session.
+func (s *smtpServer) received() smtpServer {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return smtpServer{auth: s.auth, upgraded: s.upgraded, from: s.from, rcpt: append([]string(nil), s.rcpt...), data: s.data}
+}
+
+func (s *smtpServer) serve() {
+	for {
+		conn, err := s.listener.Accept()
+		if err != nil {
+			return
+		}
+		go s.handle(conn)
+	}
+}
+
+func (s *smtpServer) handle(conn net.Conn) {
+	defer conn.Close()
+	reader := bufio.NewReader(conn)
+	reply := func(line string) { io.WriteString(conn, line+"\r\n") }
+
+	reply("220 localhost ESMTP")
+	for {
+		line, err := reader.ReadString('\n')
+		if err != nil {
+			return
+		}
+		command, argument, _ := strings.Cut(strings.TrimSpace(line), " ")
+
+		s.mu.Lock()
+		switch strings.ToUpper(command) {
+		case "EHLO":
+			if s.tls != nil && !s.upgraded {
+				reply("250-localhost")
+				reply("250-STARTTLS")
+			} else {
+				reply("250-localhost")
+			}
+			reply("250 AUTH PLAIN")
+		case "STARTTLS":
+			reply("220 ready to start TLS")
+			tlsConn := tls.Server(conn, s.tls)
+			if err := tlsConn.Handshake(); err != nil {
+				s.mu.Unlock()
+				return
+			}
+			conn, reader, s.upgraded = tlsConn, bufio.NewReader(tlsConn), true
+		case "AUTH":
+			credentials, _ := base64.StdEncoding.DecodeString(strings.TrimPrefix(argument, "PLAIN "))
+			s.auth = string(credentials)
+			reply("235 authenticated")
+		case "MAIL":
+			s.from = argument
+			reply("250 ok")
+		case "RCPT":
+			if slices.Contains(s.reject, argument) {
+				reply("550 no such user")
+				break
+			}
+			s.rcpt = append(s.rcpt, argument)
+			reply("250 ok")
+		case "DATA":
+			reply("354 go ahead")
+			var data strings.Builder
+			for {
+				line, err := reader.ReadString('\n')
+				if err != nil || line == ".\r\n" {
+					break
+				}
+				data.WriteString(line)
+			}
+			s.data = data.String()
+			reply("250 ok")
+		case "QUIT":
+			reply("221 bye")
+			s.mu.Unlock()
+			return
+		default:
+			reply("502 not implemented")
+		}
+		s.mu.Unlock()
+	}
+}
+
+func (suite *EmailSuite) message(server *smtpServer) (*mail.Message, map[string]string) {
+	message, err := mail.ReadMessage(strings.NewReader(server.received().data))
+	suite.Require().NoError(err)
+
+	_, params, err := mime.ParseMediaType(message.Header.Get("Content-Type"))
+	suite.Require().NoError(err)
+
+	parts := map[string]string{}
+	reader := multipart.NewReader(message.Body, params["boundary"])
+	for {
+		part, err := reader.NextPart()
+		if err == io.EOF {
+			break
+		}
+		suite.Require().NoError(err)
+		content, err := io.ReadAll(part)
+		suite.Require().NoError(err)
+		mediaType, _, _ := mime.ParseMediaType(part.Header.Get("Content-Type"))
+		parts[mediaType] = string(content)
+	}
+	return message, parts
+}
+
+func (suite *EmailSuite) podTerminated() Event {
+	pod := util.NewPodWithOwner("chaos", "foo-57df4db6b-h9ktj", v1.PodRunning, "uid")
+	pod.OwnerReferences[0].Kind = "ReplicaSet"
+	pod.OwnerReferences[0].Name = "foo-57df4db6b"
+	pod.Spec.NodeName = "node-1"
+	return Event{Type: EventPodTerminated, Time: time.Date(2026, 1, 2, 3, 4, 5, 0, time.UTC), Pod: &pod}
+}
+
+func (suite *EmailSuite) TestPlainAndHTML() {
+	server := newSMTPServer(nil)
+	defer server.Close()
+
+	email := NewEmailNotifier(server.Address(), "chaoskube@example.com", []string{"sre@example.com", "oncall@example.com"}, "", "", nil, "experiment-1")
+	email.StartTLS = false
+
+	suite.Require().NoError(email.Notify(context.Background(), suite.podTerminated()))
+
+	suite.Equal("FROM:<chaoskube@example.com>", server.received().from)
+	suite.Equal([]string{"TO:<oncall@example.com>", "TO:<sre@example.com>"}, server.received().rcpt)
+	suite.Empty(server.received().auth)
+
+	message, parts := suite.message(server)
+	suite.Equal("Chaos event - Pod termination", message.Header.Get("Subject"))
+	suite.Equal("oncall@example.com, sre@example.com", message.Header.Get("To"))
+	suite.Equal("Fri, 02 Jan 2026 03:04:05 +0000", message.Header.Get("Date"))
+	suite.Regexp(`^<[0-9a-f]{32}@example\.com>$`, message.Header.Get("Message-ID"))
+
+	suite.Equal("pod foo-57df4db6b-h9ktj has been selected by chaos-kube for termination\r\n\r\n"+
+		"namespace: chaos\r\npod: foo-57df4db6b-h9ktj\r\nowner: ReplicaSet/foo-57df4db6b\r\nnode: node-1\r\nexperiment: experiment-1\r\n", parts["text/plain"])
+	suite.Contains(parts["text/html"], "<h2>Chaos event - Pod termination</h2>")
+	suite.Contains(parts["text/html"], `<tr><th align="left">owner</th><td>ReplicaSet/foo-57df4db6b</td></tr>`)
+}
+
+func (suite *EmailSuite) TestStartTLSAndAuth() {
+	tlsServer := httptest.NewTLSServer(http.NotFoundHandler())
+	defer tlsServer.Close()
+
+	server := newSMTPServer(tlsServer.TLS)
+	defer server.Close()
+
+	email := NewEmailNotifier(server.Address(), "chaoskube@example.com", []string{"sre@example.com"}, "user", "secret", nil, "")
+	email.TLSConfig = tlsServer.Client().Transport.(*http.Transport).TLSClientConfig.Clone()
+	email.TLSConfig.ServerName = "example.com"
+
+	suite.Require().NoError(email.Notify(context.Background(), suite.podTerminated()))
+	suite.True(server.received().upgraded)
+	suite.Equal("\x00user\x00secret", server.received().auth)
+}
+
+func (suite *EmailSuite) TestStartTLSRequired() {
+	server := newSMTPServer(nil)
+	defer server.Close()
+
+	email := NewEmailNotifier(server.Address(), "chaoskube@example.com", []string{"sre@example.com"}, "", "", nil, "")
+
+	err := email.Notify(context.Background(), suite.podTerminated())
+	suite.EqualError(err, "smtp server doesn't support STARTTLS")
+}
+
+func (suite *EmailSuite) TestNamespaceRecipients() {
+	server := newSMTPServer(nil)
+	defer server.Close()
+
+	client := fake.NewSimpleClientset(&v1.Namespace{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:        "chaos",
+			Annotations: map[string]string{AnnotationEmailRecipients: "team@example.com, sre@example.com"},
+		},
+	})
+
+	email := NewEmailNotifier(server.Address(), "chaoskube@example.com", []string{"sre@example.com"}, "", "", util.NewNamespaceCache(client, time.Minute), "")
+	email.StartTLS = false
+
+	suite.Require().NoError(email.Notify(context.Background(), suite.podTerminated()))
+	suite.Equal([]string{"TO:<sre@example.com>", "TO:<team@example.com>"}, server.received().rcpt)
+
+	// events without pods only go to the global recipients
+	suite.Require().NoError(email.Notify(context.Background(), Event{Type: EventIntervalSkipped, Reason: "weekday"}))
+	suite.Equal([]string{"TO:<sre@example.com>", "TO:<team@example.com>", "TO:<sre@example.com>"}, server.received().rcpt)
+
+	// unknown namespaces are logged and the global recipients are still notified
+	logger, logOutput := test.NewNullLogger()
+	email.Logger = logger
+	pod := util.NewPod("unknown", "foo", v1.PodRunning)
+	suite.Require().NoError(email.Notify(context.Background(), Event{Type: EventPodTerminated, Pod: &pod}))
+	suite.Equal([]string{"TO:<sre@example.com>", "TO:<team@example.com>", "TO:<sre@example.com>", "TO:<sre@example.com>"}, server.received().rcpt)
+	suite.AssertLog(logOutput, log.WarnLevel, "failed to look up email recipients", log.Fields{})
+	suite.ErrorContains(logOutput.LastEntry().Data["err"].(error), "failed to look up email recipients of namespace unknown")
+}
+
+func (suite *EmailSuite) TestStableMessageID() {
+	server := newSMTPServer(nil)
+	defer server.Close()
+
+	email := NewEmailNotifier(server.Address(), "chaoskube@example.com", []string{"sre@example.com"}, "", "", nil, "")
+	email.StartTLS = false
+
+	messageID := func(event Event) string {
+		suite.Require().NoError(email.Notify(context.Background(), event))
+		message, _ := suite.message(server)
+		return message.Header.Get("Message-ID")
+	}
+
+	// retries of the same event keep the Message-ID
+	first := messageID(suite.podTerminated())
+	suite.Equal(first, messageID(suite.podTerminated()))
+
+	other := suite.podTerminated()
+	other.Type = EventTerminationFailed
+	suite.NotEqual(first, messageID(other))
+
+	event := suite.podTerminated()
+	event.ID = "7b1c"
+	suite.Equal("<7b1c@example.com>", messageID(event))
+}
+
+func (suite *EmailSuite) TestRejectedRecipients() {
+	server := newSMTPServer(nil)
+	defer server.Close()
+
+	client := fake.NewSimpleClientset(&v1.Namespace{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:        "chaos",
+			Annotations: map[string]string{AnnotationEmailRecipients: "typo@example, team@example.com"},
+		},
+	})
+
+	logger, logOutput := test.NewNullLogger()
+	email := NewEmailNotifier(server.Address(), "chaoskube@example.com", []string{"sre@example.com"}, "", "", util.NewNamespaceCache(client, time.Minute), "")
+	email.StartTLS = false
+	email.Logger = logger
+
+	// rejected annotated recipients are logged and skipped
+	server.reject = []string{"TO:<typo@example>"}
+	suite.Require().NoError(email.Notify(context.Background(), suite.podTerminated()))
+	suite.Equal([]string{"TO:<sre@example.com>", "TO:<team@example.com>"}, server.received().rcpt)
+	suite.AssertLog(logOutput, log.WarnLevel, "email recipient rejected", log.Fields{"recipient": "typo@example"})
+
+	// a rejected global recipient fails the notification
+	server.reject = []string{"TO:<sre@example.com>"}
+	suite.Error(email.Notify(context.Background(), suite.podTerminated()))
+
+	// so does rejecting all recipients
+	email.To = nil
+	server.reject = []string{"TO:<typo@example>", "TO:<team@example.com>"}
+	suite.EqualError(email.Notify(context.Background(), suite.podTerminated()), "smtp server rejected all recipients")
+}
+
+func (suite *EmailSuite) TestNoRecipients() {
+	email := NewEmailNotifier("127.0.0.1:0", "chaoskube@example.com", nil, "", "", nil, "")
+	suite.NoError(email.Notify(context.Background(), suite.podTerminated()))
+}
+
+func TestEmailSuite(t *testing.T) {
+	suite.Run(t, new(EmailSuite))
+}
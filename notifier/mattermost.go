@@ -0,0 +1,64 @@
+package notifier
+
+import (
+	"context"
+	"net/http"
+)
+
+const NotifierMattermost = "mattermost"
+
+// Mattermost posts a message attachment to a Mattermost incoming webhook.
+// Mattermost understands Slack's attachment format, so the message looks
+// the same as the one sent to Slack.
+type Mattermost struct {
+	Subscription
+	Webhook string
+	// Channel overrides the webhook's default channel if set.
+	Channel string
+	Client  *http.Client
+}
+
+func NewMattermostNotifier(webhook string) *Mattermost {
+	return &Mattermost{
+		Subscription: Subscription{Events: DefaultEvents},
+		Webhook:      webhook,
+		Client:       &http.Client{Timeout: DefaultTimeout},
+	}
+}
+
+func (m Mattermost) Notify(ctx context.Context, event Event) error {
+	title, text := describe(event)
+
+	short := true
+	var fields []slackField
+	for _, field := range podFields(event) {
+		fields = append(fields, slackField{
+			Title: field[0],
+			Value: field[1],
+			Short: &short,
+		})
+	}
+
+	message := createSlackRequest(title, text, fields)
+	message.Attachments[0].Fallback = text
+	message.Channel = m.Channel
+	return postJSON(ctx, m.Client, NotifierMattermost, m.Webhook, message)
+}
+
+// Route returns a copy of the notifier sending to the route's webhook or channel.
+func (m Mattermost) Route(route Route) (Notifier, bool) {
+	if route.Webhook == "" && route.Channel == "" {
+		return nil, false
+	}
+	if route.Webhook != "" {
+		m.Webhook = route.Webhook
+	}
+	if route.Channel != "" {
+		m.Channel = route.Channel
+	}
+	return &m, true
+}
+
+func (m Mattermost) Name() string {
+	return NotifierMattermost
+}
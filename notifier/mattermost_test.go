@@ -0,0 +1,65 @@
+package notifier
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	v1 "k8s.io/api/core/v1"
+
+	"github.com/linki/chaoskube/internal/testutil"
+	"github.com/linki/chaoskube/util"
+
+	"github.com/stretchr/testify/suite"
+)
+
+type MattermostSuite struct {
+	testutil.TestSuite
+}
+
+func (suite *MattermostSuite) TestMattermostNotificationForTermination() {
+	var message slackMessage
+	testServer := httptest.NewServer(http.HandlerFunc(func(res http.ResponseWriter, req *http.Request) {
+		suite.Require().Equal("application/json", req.Header.Get("Content-Type"))
+		suite.Require().NoError(json.NewDecoder(req.Body).Decode(&message))
+		res.WriteHeader(http.StatusOK)
+	}))
+	defer testServer.Close()
+
+	testPod := util.NewPod("chaos", "chaos-57df4db6b-h9ktj", v1.PodRunning)
+
+	mattermost := NewMattermostNotifier(testServer.URL)
+	suite.Equal(DefaultTimeout, mattermost.Client.Timeout)
+
+	err := mattermost.Notify(context.Background(), Event{Type: EventPodTerminated, Pod: &testPod})
+	suite.Require().NoError(err)
+
+	suite.Require().Len(message.Attachments, 1)
+	attachment := message.Attachments[0]
+	suite.Equal("Chaos event - Pod termination", attachment.Title)
+	suite.Equal("pod chaos-57df4db6b-h9ktj has been selected by chaos-kube for termination", attachment.Fallback)
+	suite.Equal(NotificationColor, attachment.Color)
+	suite.Require().Len(attachment.Fields, 2)
+	suite.Equal("chaos", attachment.Fields[0].Value)
+	suite.Equal("chaos-57df4db6b-h9ktj", attachment.Fields[1].Value)
+}
+
+func (suite *MattermostSuite) TestMattermostNotificationForTerminationStatus500() {
+	testServer := httptest.NewServer(http.HandlerFunc(func(res http.ResponseWriter, req *http.Request) {
+		res.WriteHeader(500)
+	}))
+	defer testServer.Close()
+
+	testPod := util.NewPod("chaos", "chaos-57df4db6b-h9ktj", v1.PodRunning)
+
+	mattermost := NewMattermostNotifier(testServer.URL)
+	err := mattermost.Notify(context.Background(), Event{Type: EventPodTerminated, Pod: &testPod})
+
+	suite.EqualError(err, "unexpected status code 500 from mattermost webhook "+testServer.URL)
+}
+
+func TestMattermostSuite(t *testing.T) {
+	suite.Run(t, new(MattermostSuite))
+}
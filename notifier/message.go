@@ -0,0 +1,76 @@
+package notifier
+
+import (
+	"fmt"
+	"strings"
+)
+
+// describe returns a human readable title and text for the event, used by the chat notifiers.
+func describe(event Event) (string, string) {
+	switch event.Type {
+	case EventIntervalStarted:
+		return "Chaos event - Interval started", "chaos-kube started a new interval"
+	case EventIntervalSkipped:
+		return "Chaos event - Interval skipped", fmt.Sprintf("chaos-kube skipped this interval: %s", event.Reason)
+	case EventVictimsSelected:
+		names := make([]string, 0, len(event.Victims))
+		for _, victim := range event.Victims {
+			names = append(names, victim.Namespace+"/"+victim.Name)
+		}
+		return "Chaos event - Victims selected", fmt.Sprintf("pods %s have been selected by chaos-kube for termination out of %d candidates", strings.Join(names, ", "), event.Candidates)
+	case EventTerminationAnnounced:
+		return "Chaos event - Pod termination announced", fmt.Sprintf("pod %s will be terminated by chaos-kube in %s", podName(event), event.Duration)
+	case EventTerminationCancelled:
+		return "Chaos event - Pod termination cancelled", fmt.Sprintf("termination of pod %s has been cancelled: %s", podName(event), event.Reason)
+	case EventPodTerminated:
+		return "Chaos event - Pod termination", fmt.Sprintf("pod %s has been selected by chaos-kube for termination", podName(event))
+	case EventDryRunTermination:
+		return "Chaos event - Pod termination (dry run)", fmt.Sprintf("pod %s would have been terminated by chaos-kube but dry-run mode is enabled", podName(event))
+	case EventTerminationFailed:
+		return "Chaos event - Pod termination failed", fmt.Sprintf("pod %s could not be terminated by chaos-kube: %v", podName(event), event.Error)
+	case EventIntervalCompleted:
+		return "Chaos event - Interval completed", summarize(event)
+	case EventRecoveryCompleted:
+		return "Chaos event - Recovery completed", fmt.Sprintf("pod %s has been replaced after %s", podName(event), event.Duration)
+	}
+	return "Chaos event", string(event.Type)
+}
+
+// summarize returns how many of the interval's victims were terminated.
+func summarize(event Event) string {
+	terminated, armed := 0, 0
+	for _, result := range event.Results {
+		if result.Error == nil {
+			terminated++
+			if result.Armed {
+				armed++
+			}
+		}
+	}
+	if event.DryRun && armed == 0 {
+		return fmt.Sprintf("chaos-kube would have terminated %d of %d pods but dry-run mode is enabled", terminated, len(event.Results))
+	}
+	if event.DryRun {
+		return fmt.Sprintf("chaos-kube terminated %d of %d pods in armed namespaces and would have terminated %d more but dry-run mode is enabled", armed, len(event.Results), terminated-armed)
+	}
+	return fmt.Sprintf("chaos-kube terminated %d of %d pods", terminated, len(event.Results))
+}
+
+// podName returns the name of the event's pod, if any.
+func podName(event Event) string {
+	if event.Pod == nil {
+		return ""
+	}
+	return event.Pod.Name
+}
+
+// podFields returns the namespace and name of the event's pod as title and value pairs.
+func podFields(event Event) [][2]string {
+	if event.Pod == nil {
+		return nil
+	}
+	return [][2]string{
+		{"namespace", event.Pod.Namespace},
+		{"pod", event.Pod.Name},
+	}
+}
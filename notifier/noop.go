@@ -1,16 +1,40 @@
 package notifier
 
 import (
-	v1 "k8s.io/api/core/v1"
+	"context"
+	"sync"
 )
 
 const NotifierNoop = "noop"
 
+// Noop subscribes to all events and records them without sending them anywhere.
 type Noop struct {
-	Calls int
+	Subscription
+
+	mu     sync.Mutex
+	Calls  int
+	Events []Event
 }
 
-func (t *Noop) NotifyPodTermination(pod v1.Pod) error {
+func (t *Noop) Notify(ctx context.Context, event Event) error {
+	t.mu.Lock()
+	defer t.mu.Unlock()
 	t.Calls++
+	t.Events = append(t.Events, event)
 	return nil
 }
+
+// Received returns the types of the recorded events.
+func (t *Noop) Received() []EventType {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	types := make([]EventType, 0, len(t.Events))
+	for _, event := range t.Events {
+		types = append(types, event.Type)
+	}
+	return types
+}
+
+func (t *Noop) Name() string {
+	return NotifierNoop
+}
@@ -1,12 +1,145 @@
 package notifier
 
 import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"time"
+
 	multierror "github.com/hashicorp/go-multierror"
 	v1 "k8s.io/api/core/v1"
 )
 
+// EventType identifies a stage of the chaos lifecycle.
+type EventType string
+
+// The stages of the chaos lifecycle notifiers can subscribe to.
+const (
+	EventIntervalStarted      EventType = "interval.started"
+	EventIntervalSkipped      EventType = "interval.skipped"
+	EventVictimsSelected      EventType = "victims.selected"
+	EventTerminationAnnounced EventType = "termination.announced"
+	EventTerminationCancelled EventType = "termination.cancelled"
+	EventPodTerminated        EventType = "pod.terminated"
+	EventDryRunTermination    EventType = "dryrun.termination"
+	EventTerminationFailed    EventType = "termination.failed"
+	EventIntervalCompleted    EventType = "interval.completed"
+	EventRecoveryCompleted    EventType = "recovery.completed"
+)
+
+// EventTypes lists all event types in the order they happen during an interval.
+var EventTypes = []EventType{
+	EventIntervalStarted,
+	EventIntervalSkipped,
+	EventVictimsSelected,
+	EventTerminationAnnounced,
+	EventTerminationCancelled,
+	EventPodTerminated,
+	EventDryRunTermination,
+	EventTerminationFailed,
+	EventIntervalCompleted,
+	EventRecoveryCompleted,
+}
+
+// DefaultEvents are the event types the chat notifiers and the webhook subscribe to by default.
+// Dry-run terminations are included so that a rehearsal looks like the real thing.
+var DefaultEvents = []EventType{EventPodTerminated, EventDryRunTermination, EventTerminationAnnounced, EventTerminationCancelled}
+
+// ParseEventType returns the EventType with the given name.
+func ParseEventType(name string) (EventType, error) {
+	for _, t := range EventTypes {
+		if string(t) == name {
+			return t, nil
+		}
+	}
+	return "", fmt.Errorf("unknown event type: %s", name)
+}
+
+// Event describes something that happened during an interval.
+type Event struct {
+	// ID identifies the event, it stays the same when delivering the event is retried.
+	// Empty IDs are derived from the event's type, pod and time.
+	ID string
+	// Type is the stage of the chaos lifecycle.
+	Type EventType
+	// Time is when the event happened.
+	Time time.Time
+	// Pod is the pod the event is about, set for announcement, termination and recovery events.
+	Pod *v1.Pod
+	// Victims are the pods selected for termination, set for EventVictimsSelected and EventIntervalCompleted.
+	Victims []v1.Pod
+	// Results are the outcomes of the interval's terminations, set for EventIntervalCompleted.
+	Results []Result
+	// Candidates is the number of pods that were eligible for termination, set for EventVictimsSelected.
+	Candidates int
+	// Reason is why the interval was skipped or the termination cancelled, set for
+	// EventIntervalSkipped and EventTerminationCancelled.
+	Reason string
+	// Error is why the termination failed, set for EventTerminationFailed.
+	Error error
+	// Duration is the time until the announced termination for EventTerminationAnnounced
+	// and how long it took the pod's owner to recover for EventRecoveryCompleted.
+	Duration time.Duration
+	// DryRun is true if chaoskube ran in dry-run mode.
+	DryRun bool
+}
+
+// Result is the outcome of terminating a single victim.
+type Result struct {
+	Pod v1.Pod
+	// Error is why the termination failed, nil if it succeeded.
+	Error error
+	// Armed is true if the pod was terminated in dry-run mode because its namespace is armed.
+	Armed bool
+}
+
+// eventID returns the ID of the event, deriving one from its type, pod and time if it has none.
+func eventID(event Event) string {
+	if event.ID != "" {
+		return event.ID
+	}
+	hash := sha256.New()
+	fmt.Fprintf(hash, "%s\x00%d", event.Type, event.Time.UnixNano())
+	if event.Pod != nil {
+		fmt.Fprintf(hash, "\x00%s", event.Pod.UID)
+	}
+	return hex.EncodeToString(hash.Sum(nil)[:16])
+}
+
+// eventTime returns the time of the event in UTC, defaulting to now.
+func eventTime(event Event) time.Time {
+	if event.Time.IsZero() {
+		return time.Now().UTC()
+	}
+	return event.Time.UTC()
+}
+
+// Notifier is the interface for implementations that notify about the chaos lifecycle.
 type Notifier interface {
-	NotifyPodTermination(pod v1.Pod) error
+	// Subscribes returns whether the notifier wants to receive events of the given type.
+	Subscribes(eventType EventType) bool
+	// Notify sends the given event.
+	Notify(ctx context.Context, event Event) error
+}
+
+// Subscription is embedded by notifiers to receive only the listed event types.
+// An empty subscription receives all event types.
+type Subscription struct {
+	Events []EventType
+}
+
+// Subscribes returns whether eventType is part of the subscription.
+func (s Subscription) Subscribes(eventType EventType) bool {
+	if len(s.Events) == 0 {
+		return true
+	}
+	for _, t := range s.Events {
+		if t == eventType {
+			return true
+		}
+	}
+	return false
 }
 
 type Notifiers struct {
@@ -17,10 +150,24 @@ func New() *Notifiers {
 	return &Notifiers{notifiers: make([]Notifier, 0)}
 }
 
-func (m *Notifiers) NotifyPodTermination(pod v1.Pod) error {
+// Subscribes returns whether any of the notifiers subscribes to eventType.
+func (m *Notifiers) Subscribes(eventType EventType) bool {
+	for _, n := range m.notifiers {
+		if n.Subscribes(eventType) {
+			return true
+		}
+	}
+	return false
+}
+
+// Notify sends the event to all notifiers that subscribe to its type.
+func (m *Notifiers) Notify(ctx context.Context, event Event) error {
 	var result error
 	for _, n := range m.notifiers {
-		if err := n.NotifyPodTermination(pod); err != nil {
+		if !n.Subscribes(event.Type) {
+			continue
+		}
+		if err := n.Notify(ctx, event); err != nil {
 			result = multierror.Append(result, err)
 		}
 	}
@@ -1,11 +1,11 @@
 package notifier
 
 import (
+	"context"
 	"fmt"
-	"github.com/hashicorp/go-multierror"
 	"testing"
 
-	v1 "k8s.io/api/core/v1"
+	"github.com/hashicorp/go-multierror"
 
 	"github.com/linki/chaoskube/internal/testutil"
 
@@ -16,23 +16,28 @@ type NotifierSuite struct {
 	testutil.TestSuite
 }
 
-type FailingNotifier struct{}
+type FailingNotifier struct {
+	Subscription
+}
 
-func (f FailingNotifier) NotifyPodTermination(pod v1.Pod) error {
+func (f FailingNotifier) Notify(ctx context.Context, event Event) error {
 	return fmt.Errorf("notify error")
 }
 
+var podTerminated = Event{Type: EventPodTerminated}
+
 func (suite *NotifierSuite) TestMultiNotifierWithoutNotifiers() {
 	manager := New()
-	err := manager.NotifyPodTermination(v1.Pod{})
+	err := manager.Notify(context.Background(), podTerminated)
 	suite.NoError(err)
+	suite.False(manager.Subscribes(EventPodTerminated))
 }
 
 func (suite *NotifierSuite) TestMultiNotifierWithNotifier() {
 	manager := New()
 	n := Noop{}
 	manager.Add(&n)
-	err := manager.NotifyPodTermination(v1.Pod{})
+	err := manager.Notify(context.Background(), podTerminated)
 	suite.Require().NoError(err)
 
 	suite.Equal(1, n.Calls)
@@ -45,7 +50,7 @@ func (suite *NotifierSuite) TestMultiNotifierWithMultipleNotifier() {
 	manager.Add(&n1)
 	manager.Add(&n2)
 
-	err := manager.NotifyPodTermination(v1.Pod{})
+	err := manager.Notify(context.Background(), podTerminated)
 	suite.Require().NoError(err)
 
 	suite.Equal(1, n1.Calls)
@@ -56,7 +61,7 @@ func (suite *NotifierSuite) TestMultiNotifierWithNotifierError() {
 	manager := New()
 	f := FailingNotifier{}
 	manager.Add(&f)
-	err := manager.NotifyPodTermination(v1.Pod{})
+	err := manager.Notify(context.Background(), podTerminated)
 	suite.Require().Error(err)
 }
 
@@ -66,7 +71,7 @@ func (suite *NotifierSuite) TestMultiNotifierWithNotifierMultipleError() {
 	f1 := FailingNotifier{}
 	manager.Add(&f0)
 	manager.Add(&f1)
-	err := manager.NotifyPodTermination(v1.Pod{}).(*multierror.Error)
+	err := manager.Notify(context.Background(), podTerminated).(*multierror.Error)
 	suite.Require().Error(err)
 	suite.Require().Len(err.Errors, 2)
 }
@@ -77,11 +82,43 @@ func (suite *NotifierSuite) TestMultiNotifierWithOneFailingNotifier() {
 	n := Noop{}
 	manager.Add(&n)
 	manager.Add(&f)
-	err := manager.NotifyPodTermination(v1.Pod{}).(*multierror.Error)
+	err := manager.Notify(context.Background(), podTerminated).(*multierror.Error)
 	suite.Require().Error(err)
 	suite.Require().Len(err.Errors, 1)
 }
 
+func (suite *NotifierSuite) TestMultiNotifierSubscriptions() {
+	manager := New()
+	all := Noop{}
+	skipped := Noop{Subscription: Subscription{Events: []EventType{EventIntervalSkipped}}}
+	failing := FailingNotifier{Subscription: Subscription{Events: []EventType{EventTerminationFailed}}}
+	manager.Add(&all)
+	manager.Add(&skipped)
+	manager.Add(&failing)
+
+	suite.True(manager.Subscribes(EventIntervalSkipped))
+	suite.True(manager.Subscribes(EventRecoveryCompleted))
+
+	suite.Require().NoError(manager.Notify(context.Background(), Event{Type: EventIntervalStarted}))
+	suite.Require().NoError(manager.Notify(context.Background(), Event{Type: EventIntervalSkipped, Reason: "paused"}))
+	suite.Require().Error(manager.Notify(context.Background(), Event{Type: EventTerminationFailed}))
+
+	suite.Equal([]EventType{EventIntervalStarted, EventIntervalSkipped, EventTerminationFailed}, all.Received())
+	suite.Equal([]EventType{EventIntervalSkipped}, skipped.Received())
+	suite.Equal("paused", skipped.Events[0].Reason)
+}
+
+func (suite *NotifierSuite) TestParseEventType() {
+	for _, eventType := range EventTypes {
+		parsed, err := ParseEventType(string(eventType))
+		suite.Require().NoError(err)
+		suite.Equal(eventType, parsed)
+	}
+
+	_, err := ParseEventType("pod.deleted")
+	suite.EqualError(err, "unknown event type: pod.deleted")
+}
+
 func TestNotifierSuite(t *testing.T) {
 	suite.Run(t, new(NotifierSuite))
 }
@@ -0,0 +1,163 @@
+package notifier
+
+import (
+	"context"
+	"fmt"
+	"slices"
+
+	multierror "github.com/hashicorp/go-multierror"
+
+	"github.com/linki/chaoskube/util"
+)
+
+// Annotations on namespaces that route notifications about their pods to the namespace's own destinations.
+const (
+	AnnotationSlackWebhook      = "chaoskube.io/slack-webhook"
+	AnnotationTeamsWebhook      = "chaoskube.io/teams-webhook"
+	AnnotationMattermostWebhook = "chaoskube.io/mattermost-webhook"
+	AnnotationWebhookURL        = "chaoskube.io/webhook-url"
+	AnnotationNotifyChannel     = "chaoskube.io/notify-channel"
+)
+
+// Routing modes that decide whether the global destination is notified as well.
+const (
+	RoutingOff     = "off"
+	RoutingAdd     = "add"
+	RoutingReplace = "replace"
+)
+
+// Route is a destination annotated on a namespace.
+type Route struct {
+	Webhook string
+	Channel string
+}
+
+// Routable is implemented by notifiers whose destination can be overridden per namespace.
+type Routable interface {
+	Notifier
+	// Route returns a copy of the notifier sending to the given route
+	// or false if the route doesn't apply to the notifier.
+	Route(route Route) (Notifier, bool)
+}
+
+// Router sends events about pods to the destinations annotated on their namespaces.
+// Events without a pod or about pods in namespaces without annotations go to the
+// wrapped notifier's global destination. Unless Replace is set, so do all others.
+// Each destination only learns about the pods in its own namespaces.
+type Router struct {
+	Notifier Routable
+	// Annotation holds the namespace annotation that overrides the webhook, e.g. AnnotationSlackWebhook.
+	Annotation string
+	Namespaces *util.NamespaceCache
+	Replace    bool
+}
+
+// NewRouter returns a Router for the notifier in the given routing mode that looks up
+// the annotations of the namespaces in the given cache.
+func NewRouter(notifier Routable, annotation string, namespaces *util.NamespaceCache, mode string) *Router {
+	return &Router{
+		Notifier:   notifier,
+		Annotation: annotation,
+		Namespaces: namespaces,
+		Replace:    mode == RoutingReplace,
+	}
+}
+
+func (r *Router) Subscribes(eventType EventType) bool {
+	return r.Notifier.Subscribes(eventType)
+}
+
+// Notify sends the event to all of its destinations. The Dispatcher doesn't call it but
+// retries each destination on its own, see split.
+func (r *Router) Notify(ctx context.Context, event Event) error {
+	parts, result := r.split(ctx, event)
+	for _, part := range parts {
+		if err := part.notifier.Notify(ctx, part.event); err != nil {
+			result = multierror.Append(result, err)
+		}
+	}
+	return result
+}
+
+// split returns the destinations of the event along with the part of the event each of
+// them receives. Namespaces whose route can't be looked up go to the global destination,
+// the lookup errors are returned alongside.
+func (r *Router) split(ctx context.Context, event Event) ([]part, error) {
+	var result error
+
+	routes := []Route{}
+	namespaces := map[Route][]string{}
+	unrouted := []string{}
+	for _, namespace := range eventNamespaces(event) {
+		route, err := r.route(ctx, namespace)
+		if err != nil {
+			result = multierror.Append(result, err)
+			unrouted = append(unrouted, namespace)
+			continue
+		}
+		if _, ok := r.Notifier.Route(route); !ok {
+			unrouted = append(unrouted, namespace)
+			continue
+		}
+		if _, ok := namespaces[route]; !ok {
+			routes = append(routes, route)
+		}
+		namespaces[route] = append(namespaces[route], namespace)
+	}
+
+	parts := []part{}
+	for _, route := range routes {
+		notifier, _ := r.Notifier.Route(route)
+		parts = append(parts, part{notifier: notifier, event: scope(event, namespaces[route])})
+	}
+
+	switch {
+	case !r.Replace || len(routes) == 0:
+		parts = append(parts, part{notifier: r.Notifier, event: event})
+	case len(unrouted) > 0:
+		parts = append(parts, part{notifier: r.Notifier, event: scope(event, unrouted)})
+	}
+
+	return parts, result
+}
+
+// route returns the destination annotated on the given namespace.
+func (r *Router) route(ctx context.Context, name string) (Route, error) {
+	namespace, err := r.Namespaces.Get(ctx, name)
+	if err != nil {
+		return Route{}, fmt.Errorf("failed to look up notification route of namespace %s: %w", name, err)
+	}
+	return Route{
+		Webhook: namespace.Annotations[r.Annotation],
+		Channel: namespace.Annotations[AnnotationNotifyChannel],
+	}, nil
+}
+
+func (r *Router) Name() string {
+	return Name(r.Notifier)
+}
+
+// scope returns a copy of the event that only contains the pods in the given namespaces.
+func scope(event Event, namespaces []string) Event {
+	if event.Pod != nil && !slices.Contains(namespaces, event.Pod.Namespace) {
+		event.Pod = nil
+	}
+
+	victims := event.Victims
+	event.Victims = nil
+	for _, victim := range victims {
+		if slices.Contains(namespaces, victim.Namespace) {
+			event.Victims = append(event.Victims, victim)
+		}
+	}
+
+	results := event.Results
+	event.Results = nil
+	for _, result := range results {
+		if slices.Contains(namespaces, result.Pod.Namespace) {
+			event.Results = append(event.Results, result)
+		}
+	}
+
+	return event
+}
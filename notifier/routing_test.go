@@ -0,0 +1,245 @@
+package notifier
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+	"time"
+
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes/fake"
+
+	"github.com/sirupsen/logrus/hooks/test"
+
+	"github.com/linki/chaoskube/internal/testutil"
+	"github.com/linki/chaoskube/metrics"
+	"github.com/linki/chaoskube/util"
+
+	"github.com/stretchr/testify/suite"
+)
+
+type RoutingSuite struct {
+	testutil.TestSuite
+}
+
+// routed records the path and channel of a message posted to the test server.
+type routed struct {
+	Path    string
+	Channel string
+}
+
+func (suite *RoutingSuite) newServer() (*httptest.Server, func() []routed) {
+	var mu sync.Mutex
+	requests := []routed{}
+	server := httptest.NewServer(http.HandlerFunc(func(res http.ResponseWriter, req *http.Request) {
+		var message slackMessage
+		suite.Require().NoError(json.NewDecoder(req.Body).Decode(&message))
+		mu.Lock()
+		requests = append(requests, routed{Path: req.URL.Path, Channel: message.Channel})
+		mu.Unlock()
+	}))
+	return server, func() []routed {
+		mu.Lock()
+		defer mu.Unlock()
+		return append([]routed{}, requests...)
+	}
+}
+
+func (suite *RoutingSuite) TestRouter() {
+	server, requests := suite.newServer()
+	defer server.Close()
+
+	client := fake.NewSimpleClientset(
+		&v1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: "default"}},
+		&v1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: "payments", Annotations: map[string]string{
+			AnnotationSlackWebhook: server.URL + "/payments",
+		}}},
+		&v1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: "search", Annotations: map[string]string{
+			AnnotationNotifyChannel: "#search",
+		}}},
+	)
+
+	pod := func(namespace string) *v1.Pod {
+		pod := util.NewPod(namespace, "foo", v1.PodRunning)
+		return &pod
+	}
+
+	for _, tt := range []struct {
+		name     string
+		mode     string
+		event    Event
+		expected []routed
+		err      string
+	}{
+		{
+			name:     "no annotations",
+			mode:     RoutingAdd,
+			event:    Event{Type: EventPodTerminated, Pod: pod("default")},
+			expected: []routed{{Path: "/global"}},
+		},
+		{
+			name:     "webhook in addition to global",
+			mode:     RoutingAdd,
+			event:    Event{Type: EventPodTerminated, Pod: pod("payments")},
+			expected: []routed{{Path: "/payments"}, {Path: "/global"}},
+		},
+		{
+			name:     "webhook instead of global",
+			mode:     RoutingReplace,
+			event:    Event{Type: EventPodTerminated, Pod: pod("payments")},
+			expected: []routed{{Path: "/payments"}},
+		},
+		{
+			name:     "channel on the global webhook",
+			mode:     RoutingReplace,
+			event:    Event{Type: EventPodTerminated, Pod: pod("search")},
+			expected: []routed{{Path: "/global", Channel: "#search"}},
+		},
+		{
+			name:     "victims from several namespaces",
+			mode:     RoutingReplace,
+			event:    Event{Type: EventVictimsSelected, Victims: []v1.Pod{*pod("payments"), *pod("search"), *pod("payments")}},
+			expected: []routed{{Path: "/payments"}, {Path: "/global", Channel: "#search"}},
+		},
+		{
+			name:     "events without pods",
+			mode:     RoutingReplace,
+			event:    Event{Type: EventIntervalSkipped},
+			expected: []routed{{Path: "/global"}},
+		},
+		{
+			name:     "unknown namespace falls back to global",
+			mode:     RoutingReplace,
+			event:    Event{Type: EventPodTerminated, Pod: pod("unknown")},
+			expected: []routed{{Path: "/global"}},
+			err:      "failed to look up notification route of namespace unknown",
+		},
+	} {
+		before := len(requests())
+
+		router := NewRouter(NewSlackNotifier(server.URL+"/global"), AnnotationSlackWebhook, util.NewNamespaceCache(client, time.Minute), tt.mode)
+		err := router.Notify(context.Background(), tt.event)
+		if tt.err != "" {
+			suite.ErrorContains(err, tt.err, tt.name)
+		} else {
+			suite.NoError(err, tt.name)
+		}
+
+		suite.Equal(tt.expected, requests()[before:], tt.name)
+	}
+}
+
+func (suite *RoutingSuite) TestRoute() {
+	teams := NewTeamsNotifier("http://global")
+	_, ok := teams.Route(Route{Channel: "#team"})
+	suite.False(ok)
+
+	routed, ok := teams.Route(Route{Webhook: "http://team"})
+	suite.Require().True(ok)
+	suite.Equal("http://team", routed.(*Teams).Webhook)
+	suite.Equal("http://global", teams.Webhook)
+
+	router := NewRouter(teams, AnnotationTeamsWebhook, nil, RoutingAdd)
+	suite.Equal("teams", Name(router))
+	suite.True(router.Subscribes(EventPodTerminated))
+	suite.False(router.Subscribes(EventIntervalStarted))
+
+	// the global headers and secret aren't sent to a team's webhook
+	webhook, err := NewWebhookNotifier("http://global", "", map[string]string{"Authorization": "Bearer xyz"}, "secret", "")
+	suite.Require().NoError(err)
+	routed, ok = webhook.Route(Route{Webhook: "http://team"})
+	suite.Require().True(ok)
+	suite.Equal("http://team", routed.(*Webhook).URL)
+	suite.Empty(routed.(*Webhook).Headers)
+	suite.Empty(routed.(*Webhook).Secret)
+	suite.Equal("secret", webhook.Secret)
+}
+
+// routable is a test notifier for the global destination that routes to the
+// destinations registered for a webhook.
+type routable struct {
+	Notifier
+	destinations map[string]Notifier
+}
+
+func (r routable) Route(route Route) (Notifier, bool) {
+	destination, ok := r.destinations[route.Webhook]
+	return destination, ok
+}
+
+func (suite *RoutingSuite) namespaces() *util.NamespaceCache {
+	return util.NewNamespaceCache(fake.NewSimpleClientset(
+		&v1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: "default"}},
+		&v1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: "payments", Annotations: map[string]string{AnnotationWebhookURL: "payments"}}},
+		&v1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: "search", Annotations: map[string]string{AnnotationWebhookURL: "search"}}},
+	), time.Minute)
+}
+
+func (suite *RoutingSuite) TestRouterScopesEvents() {
+	global, payments, search := &Noop{}, &Noop{}, &Noop{}
+	notifier := routable{Notifier: global, destinations: map[string]Notifier{"payments": payments, "search": search}}
+
+	victims := []v1.Pod{
+		util.NewPod("payments", "foo", v1.PodRunning),
+		util.NewPod("search", "bar", v1.PodRunning),
+		util.NewPod("default", "baz", v1.PodRunning),
+	}
+	event := Event{
+		Type:    EventIntervalCompleted,
+		Victims: victims,
+		Results: []Result{{Pod: victims[0]}, {Pod: victims[1]}, {Pod: victims[2]}},
+	}
+
+	router := NewRouter(notifier, AnnotationWebhookURL, suite.namespaces(), RoutingReplace)
+	suite.Require().NoError(router.Notify(context.Background(), event))
+
+	// each team only learns about its own pods, the global destination gets the rest
+	for _, tt := range []struct {
+		notifier *Noop
+		pod      v1.Pod
+	}{
+		{payments, victims[0]},
+		{search, victims[1]},
+		{global, victims[2]},
+	} {
+		suite.Require().Len(tt.notifier.Events, 1)
+		suite.Equal([]v1.Pod{tt.pod}, tt.notifier.Events[0].Victims)
+		suite.Equal([]Result{{Pod: tt.pod}}, tt.notifier.Events[0].Results)
+	}
+
+	// in addition to the teams, the global destination gets everything
+	global.Events = nil
+	router.Replace = false
+	suite.Require().NoError(router.Notify(context.Background(), event))
+	suite.Require().Len(global.Events, 1)
+	suite.Equal(victims, global.Events[0].Victims)
+}
+
+func (suite *RoutingSuite) TestDispatcherRetriesEachDestination() {
+	metrics.NotificationsTotal.Reset()
+
+	global := &flaky{name: "global"}
+	payments := &flaky{name: "payments", failures: 2}
+	notifier := routable{Notifier: global, destinations: map[string]Notifier{"payments": payments}}
+
+	logger, _ := test.NewNullLogger()
+	dispatcher := NewDispatcher(logger)
+	dispatcher.InitialBackoff = time.Millisecond
+	dispatcher.Add(NewRouter(notifier, AnnotationWebhookURL, suite.namespaces(), RoutingAdd), 0)
+
+	pod := util.NewPod("payments", "foo", v1.PodRunning)
+	suite.Require().NoError(dispatcher.Notify(context.Background(), Event{Type: EventPodTerminated, Pod: &pod}))
+	suite.Require().NoError(dispatcher.Close(context.Background()))
+
+	// the global destination isn't notified again while the team's webhook is retried
+	suite.Len(global.Calls(), 1)
+	suite.Len(payments.Calls(), 3)
+}
+
+func TestRoutingSuite(t *testing.T) {
+	suite.Run(t, new(RoutingSuite))
+}
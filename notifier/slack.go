@@ -2,12 +2,18 @@ package notifier
 
 import (
 	"bytes"
-	"encoding/json"
+	"context"
 	"fmt"
 	"net/http"
+	"strconv"
+	"strings"
+	"text/template"
 	"time"
 
 	v1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/util/duration"
+
+	"github.com/linki/chaoskube/util"
 )
 
 const NotifierSlack = "slack"
@@ -15,14 +21,59 @@ const NotifierSlack = "slack"
 var NotificationColor = "#F35A00"
 var DefaultTimeout = 10 * time.Second
 
+// SummaryEvents are the event types the Slack notifier subscribes to in summary mode,
+// where all victims of an interval are reported in a single message.
+var SummaryEvents = []EventType{EventIntervalCompleted, EventTerminationAnnounced, EventTerminationCancelled}
+
+// maxSlackResults limits the victims listed in a summary to stay within Slack's 50 blocks per message.
+const maxSlackResults = 20
+
+// Slack posts Block Kit messages to a Slack incoming webhook.
 type Slack struct {
+	Subscription
 	Webhook string
-	Client  *http.Client
+	// Channel overrides the webhook's default channel if set.
+	Channel string
+	// Experiment is shown in the footer of every message if set.
+	Experiment string
+	// Links are added to every pod in a message, e.g. to its logs or dashboards.
+	Links  []SlackLink
+	Client *http.Client
+}
+
+// SlackLink is a link whose URL is rendered from a template with the same data as the
+// webhook notifier's body, e.g. `https://logs.example.com/?q={{ .Namespace }}/{{ .Name }}`.
+type SlackLink struct {
+	Text string
+	URL  *template.Template
+}
+
+// NewSlackLink returns a SlackLink with the given text and URL template.
+func NewSlackLink(text, url string) (SlackLink, error) {
+	tmpl, err := template.New(text).Parse(url)
+	if err != nil {
+		return SlackLink{}, fmt.Errorf("invalid slack link template: %w", err)
+	}
+	return SlackLink{Text: text, URL: tmpl}, nil
 }
 
 type slackMessage struct {
+	Channel     string       `json:"channel,omitempty"`
 	Message     string       `json:"text"`
-	Attachments []attachment `json:"attachments"`
+	Blocks      []slackBlock `json:"blocks,omitempty"`
+	Attachments []attachment `json:"attachments,omitempty"`
+}
+
+type slackBlock struct {
+	Type     string      `json:"type"`
+	Text     *slackText  `json:"text,omitempty"`
+	Fields   []slackText `json:"fields,omitempty"`
+	Elements []slackText `json:"elements,omitempty"`
+}
+
+type slackText struct {
+	Type string `json:"type"`
+	Text string `json:"text"`
 }
 
 type slackField struct {
@@ -48,31 +99,122 @@ type attachment struct {
 
 func NewSlackNotifier(webhook string) *Slack {
 	return &Slack{
-		Webhook: webhook,
-		Client:  &http.Client{Timeout: DefaultTimeout},
+		Subscription: Subscription{Events: DefaultEvents},
+		Webhook:      webhook,
+		Client:       &http.Client{Timeout: DefaultTimeout},
 	}
 }
 
-func (s Slack) NotifyPodTermination(pod v1.Pod) error {
-	title := "Chaos event - Pod termination"
-	text := fmt.Sprintf("pod %s has been selected by chaos-kube for termination", pod.Name)
+func (s Slack) Notify(ctx context.Context, event Event) error {
+	message, err := s.message(event)
+	if err != nil {
+		return err
+	}
+	return s.sendSlackMessage(ctx, message)
+}
+
+// message renders the event as Block Kit blocks. Messages about a single pod list its
+// details, interval summaries list the details of every victim.
+func (s Slack) message(event Event) (slackMessage, error) {
+	title, text := describe(event)
+
+	blocks := []slackBlock{
+		{Type: "header", Text: &slackText{Type: "plain_text", Text: title}},
+		{Type: "section", Text: markdown(text)},
+	}
 
-	short := len(pod.Namespace) < 20 && len(pod.Name) < 20
-	fields := []slackField{
-		{
-			Title: "namespace",
-			Value: pod.Namespace,
-			Short: &short,
-		},
-		{
-			Title: "pod",
-			Value: pod.Name,
-			Short: &short,
-		},
+	if event.Pod != nil {
+		podBlocks, err := s.podBlocks(event, *event.Pod, "")
+		if err != nil {
+			return slackMessage{}, err
+		}
+		blocks = append(blocks, podBlocks...)
 	}
 
-	message := createSlackRequest(title, text, fields)
-	return s.sendSlackMessage(message)
+	for i, result := range event.Results {
+		if i == maxSlackResults {
+			blocks = append(blocks, slackBlock{Type: "section", Text: markdown(fmt.Sprintf("and %d more pods", len(event.Results)-maxSlackResults))})
+			break
+		}
+		outcome := "terminated"
+		if event.DryRun && !result.Armed {
+			outcome = "would have been terminated"
+		}
+		if result.Error != nil {
+			outcome = "failed: " + result.Error.Error()
+		}
+		podBlocks, err := s.podBlocks(event, result.Pod, fmt.Sprintf("*%s/%s* %s", result.Pod.Namespace, result.Pod.Name, outcome))
+		if err != nil {
+			return slackMessage{}, err
+		}
+		blocks = append(blocks, podBlocks...)
+	}
+
+	footer := []slackText{*markdown("chaos-kube")}
+	if s.Experiment != "" {
+		footer = append(footer, *markdown("experiment *" + s.Experiment + "*"))
+	}
+	if event.DryRun {
+		footer = append(footer, *markdown("*dry run*"))
+	}
+	blocks = append(blocks, slackBlock{Type: "context", Elements: footer})
+
+	return slackMessage{Channel: s.Channel, Message: text, Blocks: blocks}, nil
+}
+
+// podBlocks returns a section with the pod's details, optionally headed by text,
+// followed by the pod's links.
+func (s Slack) podBlocks(event Event, pod v1.Pod, text string) ([]slackBlock, error) {
+	section := slackBlock{Type: "section"}
+	if text != "" {
+		section.Text = markdown(text)
+	}
+	for _, field := range podDetails(pod, eventTime(event)) {
+		section.Fields = append(section.Fields, *markdown("*" + field[0] + "*\n" + field[1]))
+	}
+	blocks := []slackBlock{section}
+
+	if len(s.Links) > 0 {
+		data := newWebhookData(Event{Type: event.Type, Time: event.Time, Pod: &pod, DryRun: event.DryRun}, s.Experiment)
+		links := make([]string, 0, len(s.Links))
+		for _, link := range s.Links {
+			var url bytes.Buffer
+			if err := link.URL.Execute(&url, data); err != nil {
+				return nil, fmt.Errorf("failed to render slack link %s: %w", link.Text, err)
+			}
+			links = append(links, fmt.Sprintf("<%s|%s>", url.String(), link.Text))
+		}
+		blocks = append(blocks, slackBlock{Type: "context", Elements: []slackText{*markdown(strings.Join(links, " | "))}})
+	}
+
+	return blocks, nil
+}
+
+// podDetails returns the pod's namespace, name, owner, node, age and restarts as title and value pairs.
+func podDetails(pod v1.Pod, now time.Time) [][2]string {
+	details := [][2]string{
+		{"Namespace", pod.Namespace},
+		{"Pod", pod.Name},
+	}
+	if owner := util.OwnerOf(pod); owner != nil {
+		details = append(details, [2]string{"Owner", owner.Kind + "/" + owner.Name})
+	}
+	if pod.Spec.NodeName != "" {
+		details = append(details, [2]string{"Node", pod.Spec.NodeName})
+	}
+	if !pod.CreationTimestamp.IsZero() {
+		details = append(details, [2]string{"Age", duration.HumanDuration(now.Sub(pod.CreationTimestamp.Time))})
+	}
+	restarts := int32(0)
+	for _, status := range pod.Status.ContainerStatuses {
+		restarts += status.RestartCount
+	}
+	details = append(details, [2]string{"Restarts", strconv.Itoa(int(restarts))})
+	return details
+}
+
+func markdown(text string) *slackText {
+	return &slackText{Type: "mrkdwn", Text: text}
 }
 
 func createSlackRequest(title string, text string, fields []slackField) slackMessage {
@@ -87,23 +229,24 @@ func createSlackRequest(title string, text string, fields []slackField) slackMes
 	}
 }
 
-func (s Slack) sendSlackMessage(message slackMessage) error {
-	messageBody, err := json.Marshal(message)
-	if err != nil {
-		return err
-	}
-	req, err := http.NewRequest(http.MethodPost, s.Webhook, bytes.NewBuffer(messageBody))
-	if err != nil {
-		return err
+func (s Slack) sendSlackMessage(ctx context.Context, message slackMessage) error {
+	return postJSON(ctx, s.Client, NotifierSlack, s.Webhook, message)
+}
+
+// Route returns a copy of the notifier sending to the route's webhook or channel.
+func (s Slack) Route(route Route) (Notifier, bool) {
+	if route.Webhook == "" && route.Channel == "" {
+		return nil, false
 	}
-	req.Header.Add("Content-Type", "application/json")
-	res, err := s.Client.Do(req)
-	if err != nil {
-		return err
+	if route.Webhook != "" {
+		s.Webhook = route.Webhook
 	}
-	if res.StatusCode != http.StatusOK {
-		return fmt.Errorf("unexpected status code %d from slack webhook %s", res.StatusCode, s.Webhook)
+	if route.Channel != "" {
+		s.Channel = route.Channel
 	}
+	return &s, true
+}
 
-	return nil
+func (s Slack) Name() string {
+	return NotifierSlack
 }
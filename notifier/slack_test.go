@@ -1,11 +1,16 @@
 package notifier
 
 import (
+	"context"
+	"encoding/json"
+	"errors"
 	"net/http"
 	"net/http/httptest"
 	"testing"
+	"time"
 
 	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 
 	"github.com/linki/chaoskube/internal/testutil"
 	"github.com/linki/chaoskube/util"
@@ -31,7 +36,7 @@ func (suite *SlackSuite) TestSlackNotificationForTerminationStatusOk() {
 	testPod := util.NewPod("chaos", "chaos-57df4db6b-h9ktj", v1.PodRunning)
 
 	slack := NewSlackNotifier(testServer.URL + webhookPath)
-	err := slack.NotifyPodTermination(testPod)
+	err := slack.Notify(context.Background(), Event{Type: EventPodTerminated, Pod: &testPod})
 
 	suite.NoError(err)
 }
@@ -50,11 +55,137 @@ func (suite *SlackSuite) TestSlackNotificationForTerminationStatus500() {
 	testPod := util.NewPod("chaos", "chaos-57df4db6b-h9ktj", v1.PodRunning)
 
 	slack := NewSlackNotifier(testServer.URL + webhookPath)
-	err := slack.NotifyPodTermination(testPod)
+	err := slack.Notify(context.Background(), Event{Type: EventPodTerminated, Pod: &testPod})
 
 	suite.Error(err)
 }
 
+func (suite *SlackSuite) TestSlackNotificationForTerminationStatus204() {
+	testServer := httptest.NewServer(http.HandlerFunc(func(res http.ResponseWriter, req *http.Request) {
+		res.WriteHeader(http.StatusNoContent)
+	}))
+	defer testServer.Close()
+
+	testPod := util.NewPod("chaos", "chaos-57df4db6b-h9ktj", v1.PodRunning)
+
+	slack := NewSlackNotifier(testServer.URL)
+	err := slack.Notify(context.Background(), Event{Type: EventPodTerminated, Pod: &testPod})
+
+	suite.NoError(err)
+}
+
+func (suite *SlackSuite) TestSlackNotificationForSkippedInterval() {
+	var message slackMessage
+	testServer := httptest.NewServer(http.HandlerFunc(func(res http.ResponseWriter, req *http.Request) {
+		suite.Require().NoError(json.NewDecoder(req.Body).Decode(&message))
+		res.WriteHeader(200)
+	}))
+	defer testServer.Close()
+
+	slack := NewSlackNotifier(testServer.URL)
+	suite.False(slack.Subscribes(EventIntervalSkipped))
+
+	err := slack.Notify(context.Background(), Event{Type: EventIntervalSkipped, Reason: "paused"})
+	suite.Require().NoError(err)
+
+	suite.Empty(message.Attachments)
+	suite.Equal("chaos-kube skipped this interval: paused", message.Message)
+	suite.Equal([]slackBlock{
+		{Type: "header", Text: &slackText{Type: "plain_text", Text: "Chaos event - Interval skipped"}},
+		{Type: "section", Text: markdown("chaos-kube skipped this interval: paused")},
+		{Type: "context", Elements: []slackText{*markdown("chaos-kube")}},
+	}, message.Blocks)
+}
+
+func (suite *SlackSuite) testPod() v1.Pod {
+	pod := util.NewPodWithOwner("chaos", "foo-57df4db6b-h9ktj", v1.PodRunning, "uid")
+	pod.OwnerReferences[0].Kind = "ReplicaSet"
+	pod.OwnerReferences[0].Name = "foo-57df4db6b"
+	pod.Spec.NodeName = "node-1"
+	pod.CreationTimestamp = metav1.NewTime(time.Date(2026, 1, 2, 0, 4, 5, 0, time.UTC))
+	pod.Status.ContainerStatuses = []v1.ContainerStatus{{RestartCount: 2}, {RestartCount: 1}}
+	return pod
+}
+
+func (suite *SlackSuite) TestBlockKitMessage() {
+	logs, err := NewSlackLink("Logs", "https://logs.example.com/?q={{ .Namespace }}%2F{{ .Name }}")
+	suite.Require().NoError(err)
+	dashboard, err := NewSlackLink("Dashboard", "https://grafana.example.com/d/{{ .OwnerName }}")
+	suite.Require().NoError(err)
+
+	slack := NewSlackNotifier("http://example.com")
+	slack.Experiment = "game-day"
+	slack.Links = []SlackLink{logs, dashboard}
+
+	pod := suite.testPod()
+	message, err := slack.message(Event{Type: EventDryRunTermination, Time: time.Date(2026, 1, 2, 3, 4, 5, 0, time.UTC), Pod: &pod, DryRun: true})
+	suite.Require().NoError(err)
+
+	suite.Equal([]slackBlock{
+		{Type: "header", Text: &slackText{Type: "plain_text", Text: "Chaos event - Pod termination (dry run)"}},
+		{Type: "section", Text: markdown("pod foo-57df4db6b-h9ktj would have been terminated by chaos-kube but dry-run mode is enabled")},
+		{Type: "section", Fields: []slackText{
+			*markdown("*Namespace*\nchaos"),
+			*markdown("*Pod*\nfoo-57df4db6b-h9ktj"),
+			*markdown("*Owner*\nReplicaSet/foo-57df4db6b"),
+			*markdown("*Node*\nnode-1"),
+			*markdown("*Age*\n3h"),
+			*markdown("*Restarts*\n3"),
+		}},
+		{Type: "context", Elements: []slackText{
+			*markdown("<https://logs.example.com/?q=chaos%2Ffoo-57df4db6b-h9ktj|Logs> | <https://grafana.example.com/d/foo-57df4db6b|Dashboard>"),
+		}},
+		{Type: "context", Elements: []slackText{*markdown("chaos-kube"), *markdown("experiment *game-day*"), *markdown("*dry run*")}},
+	}, message.Blocks)
+}
+
+func (suite *SlackSuite) TestIntervalSummary() {
+	slack := NewSlackNotifier("http://example.com")
+	slack.Subscription = Subscription{Events: SummaryEvents}
+	suite.True(slack.Subscribes(EventIntervalCompleted))
+	suite.False(slack.Subscribes(EventPodTerminated))
+
+	foo, bar := suite.testPod(), util.NewPod("default", "bar", v1.PodRunning)
+	message, err := slack.message(Event{
+		Type:    EventIntervalCompleted,
+		Time:    time.Date(2026, 1, 2, 3, 4, 5, 0, time.UTC),
+		Victims: []v1.Pod{foo, bar},
+		Results: []Result{{Pod: foo}, {Pod: bar, Error: errors.New("forbidden")}},
+	})
+	suite.Require().NoError(err)
+
+	suite.Require().Len(message.Blocks, 5)
+	suite.Equal("chaos-kube terminated 1 of 2 pods", message.Message)
+	suite.Equal(markdown("*chaos/foo-57df4db6b-h9ktj* terminated"), message.Blocks[2].Text)
+	suite.Len(message.Blocks[2].Fields, 6)
+	suite.Equal(markdown("*default/bar* failed: forbidden"), message.Blocks[3].Text)
+	suite.Equal([]slackText{*markdown("*Namespace*\ndefault"), *markdown("*Pod*\nbar"), *markdown("*Restarts*\n0")}, message.Blocks[3].Fields)
+
+	// armed namespaces are terminated in dry-run mode
+	message, err = slack.message(Event{
+		Type:    EventIntervalCompleted,
+		Results: []Result{{Pod: foo, Armed: true}, {Pod: bar}},
+		DryRun:  true,
+	})
+	suite.Require().NoError(err)
+	suite.Equal("chaos-kube terminated 1 of 2 pods in armed namespaces and would have terminated 1 more but dry-run mode is enabled", message.Message)
+	suite.Equal(markdown("*chaos/foo-57df4db6b-h9ktj* terminated"), message.Blocks[2].Text)
+	suite.Equal(markdown("*default/bar* would have been terminated"), message.Blocks[3].Text)
+
+	// long summaries are truncated
+	results := make([]Result, 25)
+	message, err = slack.message(Event{Type: EventIntervalCompleted, Results: results, DryRun: true})
+	suite.Require().NoError(err)
+	suite.Len(message.Blocks, 2+20+1+1)
+	suite.Equal(markdown("and 5 more pods"), message.Blocks[22].Text)
+	suite.Equal("chaos-kube would have terminated 25 of 25 pods but dry-run mode is enabled", message.Message)
+}
+
+func (suite *SlackSuite) TestInvalidLink() {
+	_, err := NewSlackLink("Logs", "{{ .Name ")
+	suite.ErrorContains(err, "invalid slack link template")
+}
+
 func TestSlackSuite(t *testing.T) {
 	suite.Run(t, new(SlackSuite))
 }
@@ -0,0 +1,103 @@
+package notifier
+
+import (
+	"context"
+	"net/http"
+)
+
+const NotifierTeams = "teams"
+
+// Teams posts an Adaptive Card to a Microsoft Teams incoming webhook or workflow.
+type Teams struct {
+	Subscription
+	Webhook string
+	Client  *http.Client
+}
+
+type teamsMessage struct {
+	Type        string            `json:"type"`
+	Attachments []teamsAttachment `json:"attachments"`
+}
+
+type teamsAttachment struct {
+	ContentType string       `json:"contentType"`
+	Content     adaptiveCard `json:"content"`
+}
+
+type adaptiveCard struct {
+	Schema  string            `json:"$schema"`
+	Type    string            `json:"type"`
+	Version string            `json:"version"`
+	Body    []adaptiveElement `json:"body"`
+}
+
+type adaptiveElement struct {
+	Type   string         `json:"type"`
+	Text   string         `json:"text,omitempty"`
+	Weight string         `json:"weight,omitempty"`
+	Size   string         `json:"size,omitempty"`
+	Color  string         `json:"color,omitempty"`
+	Wrap   bool           `json:"wrap,omitempty"`
+	Facts  []adaptiveFact `json:"facts,omitempty"`
+}
+
+type adaptiveFact struct {
+	Title string `json:"title"`
+	Value string `json:"value"`
+}
+
+func NewTeamsNotifier(webhook string) *Teams {
+	return &Teams{
+		Subscription: Subscription{Events: DefaultEvents},
+		Webhook:      webhook,
+		Client:       &http.Client{Timeout: DefaultTimeout},
+	}
+}
+
+func (t Teams) Notify(ctx context.Context, event Event) error {
+	title, text := describe(event)
+
+	var facts []adaptiveFact
+	for _, field := range podFields(event) {
+		facts = append(facts, adaptiveFact{Title: field[0], Value: field[1]})
+	}
+
+	return postJSON(ctx, t.Client, NotifierTeams, t.Webhook, createTeamsRequest(title, text, facts))
+}
+
+func createTeamsRequest(title string, text string, facts []adaptiveFact) teamsMessage {
+	message := teamsMessage{
+		Type: "message",
+		Attachments: []teamsAttachment{{
+			ContentType: "application/vnd.microsoft.card.adaptive",
+			Content: adaptiveCard{
+				Schema:  "http://adaptivecards.io/schemas/adaptive-card.json",
+				Type:    "AdaptiveCard",
+				Version: "1.4",
+				Body: []adaptiveElement{
+					{Type: "TextBlock", Text: title, Weight: "Bolder", Size: "Medium", Color: "Attention"},
+					{Type: "TextBlock", Text: text, Wrap: true},
+				},
+			},
+		}},
+	}
+	if len(facts) > 0 {
+		card := &message.Attachments[0].Content
+		card.Body = append(card.Body, adaptiveElement{Type: "FactSet", Facts: facts})
+	}
+	return message
+}
+
+// Route returns a copy of the notifier sending to the route's webhook.
+// Teams webhooks are bound to a channel, so the route's channel doesn't apply.
+func (t Teams) Route(route Route) (Notifier, bool) {
+	if route.Webhook == "" {
+		return nil, false
+	}
+	t.Webhook = route.Webhook
+	return &t, true
+}
+
+func (t Teams) Name() string {
+	return NotifierTeams
+}
@@ -0,0 +1,70 @@
+package notifier
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	v1 "k8s.io/api/core/v1"
+
+	"github.com/linki/chaoskube/internal/testutil"
+	"github.com/linki/chaoskube/util"
+
+	"github.com/stretchr/testify/suite"
+)
+
+type TeamsSuite struct {
+	testutil.TestSuite
+}
+
+func (suite *TeamsSuite) TestTeamsNotificationForTermination() {
+	var message teamsMessage
+	testServer := httptest.NewServer(http.HandlerFunc(func(res http.ResponseWriter, req *http.Request) {
+		suite.Require().Equal("application/json", req.Header.Get("Content-Type"))
+		suite.Require().NoError(json.NewDecoder(req.Body).Decode(&message))
+		res.WriteHeader(http.StatusAccepted)
+	}))
+	defer testServer.Close()
+
+	testPod := util.NewPod("chaos", "chaos-57df4db6b-h9ktj", v1.PodRunning)
+
+	teams := NewTeamsNotifier(testServer.URL)
+	suite.Equal(DefaultTimeout, teams.Client.Timeout)
+
+	err := teams.Notify(context.Background(), Event{Type: EventPodTerminated, Pod: &testPod})
+	suite.Require().NoError(err)
+
+	suite.Equal("message", message.Type)
+	suite.Require().Len(message.Attachments, 1)
+	suite.Equal("application/vnd.microsoft.card.adaptive", message.Attachments[0].ContentType)
+
+	card := message.Attachments[0].Content
+	suite.Equal("AdaptiveCard", card.Type)
+	suite.Require().Len(card.Body, 3)
+	suite.Equal("Chaos event - Pod termination", card.Body[0].Text)
+	suite.Equal("pod chaos-57df4db6b-h9ktj has been selected by chaos-kube for termination", card.Body[1].Text)
+	suite.Equal([]adaptiveFact{
+		{Title: "namespace", Value: "chaos"},
+		{Title: "pod", Value: "chaos-57df4db6b-h9ktj"},
+	}, card.Body[2].Facts)
+}
+
+func (suite *TeamsSuite) TestTeamsNotificationForTerminationStatus500() {
+	testServer := httptest.NewServer(http.HandlerFunc(func(res http.ResponseWriter, req *http.Request) {
+		res.WriteHeader(500)
+	}))
+	defer testServer.Close()
+
+	testPod := util.NewPod("chaos", "chaos-57df4db6b-h9ktj", v1.PodRunning)
+
+	teams := NewTeamsNotifier(testServer.URL)
+	err := teams.Notify(context.Background(), Event{Type: EventPodTerminated, Pod: &testPod})
+
+	suite.EqualError(err, "unexpected status code 500 from teams webhook "+testServer.URL)
+}
+
+func TestTeamsSuite(t *testing.T) {
+	suite.Run(t, new(TeamsSuite))
+}
@@ -0,0 +1,179 @@
+package notifier
+
+import (
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"text/template"
+	"time"
+
+	v1 "k8s.io/api/core/v1"
+
+	"github.com/linki/chaoskube/util"
+)
+
+const NotifierWebhook = "webhook"
+
+// SignatureHeader carries the hex encoded HMAC-SHA256 of the request body
+// when the webhook is configured with a secret, e.g. `sha256=4f2a...`.
+const SignatureHeader = "X-Chaoskube-Signature"
+
+// DefaultWebhookTemplate renders the whole WebhookData as JSON.
+const DefaultWebhookTemplate = `{{ json . }}`
+
+// WebhookData is the data passed to the webhook's body template.
+// The pod fields are only set for events about a single pod.
+type WebhookData struct {
+	Event           string            `json:"event"`
+	Time            time.Time         `json:"time"`
+	Namespace       string            `json:"namespace,omitempty"`
+	Name            string            `json:"name,omitempty"`
+	OwnerKind       string            `json:"ownerKind,omitempty"`
+	OwnerName       string            `json:"ownerName,omitempty"`
+	Node            string            `json:"node,omitempty"`
+	Labels          map[string]string `json:"labels,omitempty"`
+	Victims         []string          `json:"victims,omitempty"`
+	Reason          string            `json:"reason,omitempty"`
+	Error           string            `json:"error,omitempty"`
+	RecoverySeconds float64           `json:"recoverySeconds,omitempty"`
+	Experiment      string            `json:"experiment,omitempty"`
+	DryRun          bool              `json:"dryRun"`
+
+	// Pod gives templates access to the full pod object.
+	Pod *v1.Pod `json:"-"`
+}
+
+// Webhook posts a templated body to an arbitrary URL.
+type Webhook struct {
+	Subscription
+	URL        string
+	Template   *template.Template
+	Headers    map[string]string
+	Secret     string
+	Experiment string
+	Client     *http.Client
+}
+
+// NewWebhookNotifier returns a Webhook posting the given body template to url.
+// An empty body falls back to DefaultWebhookTemplate.
+func NewWebhookNotifier(url, body string, headers map[string]string, secret, experiment string) (*Webhook, error) {
+	if body == "" {
+		body = DefaultWebhookTemplate
+	}
+	tmpl, err := template.New(NotifierWebhook).Funcs(template.FuncMap{"json": toJSON}).Parse(body)
+	if err != nil {
+		return nil, fmt.Errorf("invalid webhook template: %w", err)
+	}
+
+	return &Webhook{
+		Subscription: Subscription{Events: DefaultEvents},
+		URL:          url,
+		Template:     tmpl,
+		Headers:      headers,
+		Secret:       secret,
+		Experiment:   experiment,
+		Client:       &http.Client{Timeout: DefaultTimeout},
+	}, nil
+}
+
+func (w Webhook) Notify(ctx context.Context, event Event) error {
+	var body bytes.Buffer
+	if err := w.Template.Execute(&body, newWebhookData(event, w.Experiment)); err != nil {
+		return fmt.Errorf("failed to render webhook template: %w", err)
+	}
+	return w.send(ctx, body.Bytes())
+}
+
+// newWebhookData collects the template data for the event.
+func newWebhookData(event Event, experiment string) WebhookData {
+	data := WebhookData{
+		Event:           string(event.Type),
+		Time:            eventTime(event),
+		Reason:          event.Reason,
+		RecoverySeconds: event.Duration.Seconds(),
+		Experiment:      experiment,
+		DryRun:          event.DryRun,
+		Pod:             event.Pod,
+	}
+	if event.Error != nil {
+		data.Error = event.Error.Error()
+	}
+	for _, victim := range event.Victims {
+		data.Victims = append(data.Victims, victim.Namespace+"/"+victim.Name)
+	}
+	if pod := event.Pod; pod != nil {
+		data.Namespace = pod.Namespace
+		data.Name = pod.Name
+		data.Node = pod.Spec.NodeName
+		data.Labels = pod.Labels
+		if owner := util.OwnerOf(*pod); owner != nil {
+			data.OwnerKind = owner.Kind
+			data.OwnerName = owner.Name
+		}
+	}
+	return data
+}
+
+func (w Webhook) send(ctx context.Context, body []byte) error {
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, w.URL, bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	for key, value := range w.Headers {
+		req.Header.Set(key, value)
+	}
+	if w.Secret != "" {
+		req.Header.Set(SignatureHeader, "sha256="+Sign([]byte(w.Secret), body))
+	}
+
+	res, err := w.Client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer res.Body.Close()
+	_, _ = io.Copy(io.Discard, res.Body)
+
+	if res.StatusCode < 200 || res.StatusCode > 299 {
+		return fmt.Errorf("unexpected status code %d from webhook %s", res.StatusCode, w.URL)
+	}
+
+	return nil
+}
+
+// Sign returns the hex encoded HMAC-SHA256 of body using secret as the key.
+func Sign(secret, body []byte) string {
+	mac := hmac.New(sha256.New, secret)
+	mac.Write(body)
+	return hex.EncodeToString(mac.Sum(nil))
+}
+
+func toJSON(v interface{}) (string, error) {
+	b, err := json.Marshal(v)
+	if err != nil {
+		return "", err
+	}
+	return string(b), nil
+}
+
+// Route returns a copy of the notifier sending to the route's URL. The headers and the
+// secret are meant for the global URL and aren't sent to the namespace's URL.
+func (w Webhook) Route(route Route) (Notifier, bool) {
+	if route.Webhook == "" {
+		return nil, false
+	}
+	w.URL = route.Webhook
+	w.Headers = nil
+	w.Secret = ""
+	return &w, true
+}
+
+func (w Webhook) Name() string {
+	return NotifierWebhook
+}
@@ -0,0 +1,174 @@
+package notifier
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	v1 "k8s.io/api/core/v1"
+
+	"github.com/linki/chaoskube/internal/testutil"
+	"github.com/linki/chaoskube/util"
+
+	"github.com/stretchr/testify/suite"
+)
+
+type WebhookSuite struct {
+	testutil.TestSuite
+}
+
+type webhookRequest struct {
+	header http.Header
+	body   []byte
+}
+
+func (suite *WebhookSuite) newServer(status int) (*httptest.Server, *[]webhookRequest) {
+	requests := []webhookRequest{}
+	server := httptest.NewServer(http.HandlerFunc(func(res http.ResponseWriter, req *http.Request) {
+		suite.Require().Equal(http.MethodPost, req.Method)
+		body, err := io.ReadAll(req.Body)
+		suite.Require().NoError(err)
+		requests = append(requests, webhookRequest{header: req.Header, body: body})
+		res.WriteHeader(status)
+	}))
+	return server, &requests
+}
+
+func (suite *WebhookSuite) podTerminated() Event {
+	pod := suite.testPod()
+	return Event{Type: EventPodTerminated, Time: time.Date(2026, 1, 2, 3, 4, 5, 0, time.UTC), Pod: &pod}
+}
+
+func (suite *WebhookSuite) testPod() v1.Pod {
+	pod := util.NewPodWithOwner("chaos", "foo-57df4db6b-h9ktj", v1.PodRunning, "uid")
+	pod.OwnerReferences[0].Kind = "ReplicaSet"
+	pod.OwnerReferences[0].Name = "foo-57df4db6b"
+	pod.Spec.NodeName = "node-1"
+	return pod
+}
+
+func (suite *WebhookSuite) TestDefaultTemplate() {
+	server, requests := suite.newServer(http.StatusOK)
+	defer server.Close()
+
+	webhook, err := NewWebhookNotifier(server.URL, "", nil, "", "experiment-1")
+	suite.Require().NoError(err)
+
+	suite.Require().NoError(webhook.Notify(context.Background(), suite.podTerminated()))
+	suite.Require().Len(*requests, 1)
+
+	request := (*requests)[0]
+	suite.Equal("application/json", request.header.Get("Content-Type"))
+	suite.Empty(request.header.Get(SignatureHeader))
+
+	var data WebhookData
+	suite.Require().NoError(json.Unmarshal(request.body, &data))
+	suite.Equal(WebhookData{
+		Event:      "pod.terminated",
+		Time:       time.Date(2026, 1, 2, 3, 4, 5, 0, time.UTC),
+		Namespace:  "chaos",
+		Name:       "foo-57df4db6b-h9ktj",
+		OwnerKind:  "ReplicaSet",
+		OwnerName:  "foo-57df4db6b",
+		Node:       "node-1",
+		Labels:     map[string]string{"app": "foo-57df4db6b-h9ktj"},
+		Experiment: "experiment-1",
+	}, data)
+}
+
+func (suite *WebhookSuite) TestCustomTemplateAndHeaders() {
+	server, requests := suite.newServer(http.StatusAccepted)
+	defer server.Close()
+
+	body := `{"text": {{ json (printf "%s/%s on %s" .Namespace .Name .Node) }}, "app": {{ json (index .Labels "app") }}, "phase": "{{ .Pod.Status.Phase }}"}`
+	headers := map[string]string{"Authorization": "Bearer token", "Content-Type": "application/vnd.foo+json"}
+
+	webhook, err := NewWebhookNotifier(server.URL, body, headers, "", "")
+	suite.Require().NoError(err)
+
+	suite.Require().NoError(webhook.Notify(context.Background(), suite.podTerminated()))
+	suite.Require().Len(*requests, 1)
+
+	request := (*requests)[0]
+	suite.Equal("Bearer token", request.header.Get("Authorization"))
+	suite.Equal("application/vnd.foo+json", request.header.Get("Content-Type"))
+	suite.JSONEq(`{"text": "chaos/foo-57df4db6b-h9ktj on node-1", "app": "foo-57df4db6b-h9ktj", "phase": "Running"}`, string(request.body))
+}
+
+func (suite *WebhookSuite) TestSignature() {
+	server, requests := suite.newServer(http.StatusOK)
+	defer server.Close()
+
+	webhook, err := NewWebhookNotifier(server.URL, `{"pod": "{{ .Name }}"}`, nil, "s3cr3t", "")
+	suite.Require().NoError(err)
+
+	suite.Require().NoError(webhook.Notify(context.Background(), suite.podTerminated()))
+	suite.Require().Len(*requests, 1)
+
+	request := (*requests)[0]
+	suite.Equal(`{"pod": "foo-57df4db6b-h9ktj"}`, string(request.body))
+	suite.Equal("sha256=6402573da95b8d17ed04af95c606d26a236749f9402620a227db4e00508966b1", request.header.Get(SignatureHeader))
+}
+
+func (suite *WebhookSuite) TestUnexpectedStatus() {
+	server, _ := suite.newServer(http.StatusInternalServerError)
+	defer server.Close()
+
+	webhook, err := NewWebhookNotifier(server.URL, "", nil, "", "")
+	suite.Require().NoError(err)
+
+	err = webhook.Notify(context.Background(), suite.podTerminated())
+	suite.EqualError(err, "unexpected status code 500 from webhook "+server.URL)
+}
+
+func (suite *WebhookSuite) TestInvalidTemplate() {
+	_, err := NewWebhookNotifier("http://example.com", "{{ .Name ", nil, "", "")
+	suite.ErrorContains(err, "invalid webhook template")
+
+	webhook, err := NewWebhookNotifier("http://example.com", "{{ .Missing }}", nil, "", "")
+	suite.Require().NoError(err)
+	err = webhook.Notify(context.Background(), suite.podTerminated())
+	suite.ErrorContains(err, "failed to render webhook template")
+}
+
+func (suite *WebhookSuite) TestLifecycleEvents() {
+	server, requests := suite.newServer(http.StatusOK)
+	defer server.Close()
+
+	webhook, err := NewWebhookNotifier(server.URL, "", nil, "", "")
+	suite.Require().NoError(err)
+	suite.True(webhook.Subscribes(EventPodTerminated))
+	suite.True(webhook.Subscribes(EventDryRunTermination))
+	suite.False(webhook.Subscribes(EventIntervalSkipped))
+
+	pod := suite.testPod()
+	at := time.Date(2026, 1, 2, 3, 4, 5, 0, time.UTC)
+	for _, event := range []Event{
+		{Type: EventIntervalSkipped, Time: at, Reason: "weekday"},
+		{Type: EventVictimsSelected, Time: at, Victims: []v1.Pod{pod}, Candidates: 3},
+		{Type: EventTerminationFailed, Time: at, Pod: &pod, Error: errors.New("forbidden"), DryRun: false},
+		{Type: EventRecoveryCompleted, Time: at, Pod: &pod, Duration: 90 * time.Second},
+	} {
+		suite.Require().NoError(webhook.Notify(context.Background(), event))
+	}
+	suite.Require().Len(*requests, 4)
+
+	suite.JSONEq(`{"event": "interval.skipped", "time": "2026-01-02T03:04:05Z", "reason": "weekday", "dryRun": false}`, string((*requests)[0].body))
+	suite.JSONEq(`{"event": "victims.selected", "time": "2026-01-02T03:04:05Z", "victims": ["chaos/foo-57df4db6b-h9ktj"], "dryRun": false}`, string((*requests)[1].body))
+
+	var failed, recovered WebhookData
+	suite.Require().NoError(json.Unmarshal((*requests)[2].body, &failed))
+	suite.Equal("forbidden", failed.Error)
+	suite.Equal("foo-57df4db6b-h9ktj", failed.Name)
+	suite.Require().NoError(json.Unmarshal((*requests)[3].body, &recovered))
+	suite.Equal(90.0, recovered.RecoverySeconds)
+}
+
+func TestWebhookSuite(t *testing.T) {
+	suite.Run(t, new(WebhookSuite))
+}
@@ -0,0 +1,115 @@
+package pause
+
+import (
+	"context"
+	"strconv"
+	"time"
+
+	log "github.com/sirupsen/logrus"
+
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/fields"
+	"k8s.io/client-go/informers"
+	"k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/tools/cache"
+)
+
+const (
+	// SourceConfigMap is the source name used for pauses requested via a ConfigMap.
+	SourceConfigMap = "configmap"
+
+	// KeyPaused is the ConfigMap key which pauses chaoskube when set to true.
+	KeyPaused = "paused"
+	// KeyUntil is the optional ConfigMap key with an RFC 3339 timestamp at which the pause expires.
+	KeyUntil = "until"
+)
+
+// ConfigMapWatcher watches a ConfigMap and pauses or resumes a Switch according to its content.
+type ConfigMapWatcher struct {
+	client    kubernetes.Interface
+	logger    log.FieldLogger
+	namespace string
+	name      string
+	sw        *Switch
+}
+
+// NewConfigMapWatcher creates and returns a ConfigMapWatcher object.
+func NewConfigMapWatcher(client kubernetes.Interface, logger log.FieldLogger, namespace, name string, sw *Switch) *ConfigMapWatcher {
+	return &ConfigMapWatcher{
+		client:    client,
+		logger:    logger.WithFields(log.Fields{"namespace": namespace, "name": name}),
+		namespace: namespace,
+		name:      name,
+		sw:        sw,
+	}
+}
+
+// Run watches the ConfigMap until the given context is canceled.
+func (w *ConfigMapWatcher) Run(ctx context.Context) {
+	factory := informers.NewSharedInformerFactoryWithOptions(w.client, 0,
+		informers.WithNamespace(w.namespace),
+		informers.WithTweakListOptions(func(options *metav1.ListOptions) {
+			options.FieldSelector = fields.OneTermEqualSelector("metadata.name", w.name).String()
+		}),
+	)
+
+	informer := factory.Core().V1().ConfigMaps().Informer()
+	_, err := informer.AddEventHandler(cache.ResourceEventHandlerFuncs{
+		AddFunc:    w.update,
+		UpdateFunc: func(_, obj interface{}) { w.update(obj) },
+		DeleteFunc: func(_ interface{}) { w.sw.Resume(SourceConfigMap) },
+	})
+	if err != nil {
+		w.logger.WithField("err", err).Error("failed to watch pause configmap")
+		return
+	}
+
+	w.logger.Info("watching pause configmap")
+
+	factory.Start(ctx.Done())
+	<-ctx.Done()
+	factory.Shutdown()
+}
+
+// update pauses or resumes the switch according to the given ConfigMap.
+func (w *ConfigMapWatcher) update(obj interface{}) {
+	configMap, ok := obj.(*v1.ConfigMap)
+	if !ok || configMap.Name != w.name {
+		return
+	}
+
+	paused, until := w.parse(configMap.Data)
+
+	if paused {
+		w.sw.Pause(SourceConfigMap, until)
+	} else {
+		w.sw.Resume(SourceConfigMap)
+	}
+}
+
+// parse reads the paused state from the ConfigMap's data. Invalid values pause chaos to be safe.
+func (w *ConfigMapWatcher) parse(data map[string]string) (bool, time.Time) {
+	value, ok := data[KeyPaused]
+	if !ok {
+		return false, time.Time{}
+	}
+
+	paused, err := strconv.ParseBool(value)
+	if err != nil {
+		w.logger.WithFields(log.Fields{KeyPaused: value, "err": err}).Warn("failed to parse pause configmap, pausing chaos")
+		return true, time.Time{}
+	}
+
+	if !paused || data[KeyUntil] == "" {
+		return paused, time.Time{}
+	}
+
+	until, err := time.Parse(time.RFC3339, data[KeyUntil])
+	if err != nil {
+		w.logger.WithFields(log.Fields{KeyUntil: data[KeyUntil], "err": err}).Warn("failed to parse pause expiry, pausing chaos indefinitely")
+		return true, time.Time{}
+	}
+
+	return true, until
+}
@@ -0,0 +1,85 @@
+package pause
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes/fake"
+
+	"github.com/linki/chaoskube/internal/testutil"
+
+	"github.com/stretchr/testify/suite"
+)
+
+type ConfigMapWatcherSuite struct {
+	testutil.TestSuite
+}
+
+func (suite *ConfigMapWatcherSuite) TestRun() {
+	client := fake.NewSimpleClientset()
+	sw := NewSwitch(logger)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	go NewConfigMapWatcher(client, logger, "chaoskube", "chaoskube-pause", sw).Run(ctx)
+
+	configMaps := client.CoreV1().ConfigMaps("chaoskube")
+
+	configMap := &v1.ConfigMap{
+		ObjectMeta: metav1.ObjectMeta{Namespace: "chaoskube", Name: "chaoskube-pause"},
+		Data:       map[string]string{KeyPaused: "true"},
+	}
+	_, err := configMaps.Create(ctx, configMap, metav1.CreateOptions{})
+	suite.Require().NoError(err)
+
+	suite.Eventually(sw.Paused, time.Second, 10*time.Millisecond)
+
+	configMap.Data[KeyPaused] = "false"
+	_, err = configMaps.Update(ctx, configMap, metav1.UpdateOptions{})
+	suite.Require().NoError(err)
+
+	suite.Eventually(func() bool { return !sw.Paused() }, time.Second, 10*time.Millisecond)
+
+	configMap.Data[KeyPaused] = "true"
+	_, err = configMaps.Update(ctx, configMap, metav1.UpdateOptions{})
+	suite.Require().NoError(err)
+
+	suite.Eventually(sw.Paused, time.Second, 10*time.Millisecond)
+
+	err = configMaps.Delete(ctx, configMap.Name, metav1.DeleteOptions{})
+	suite.Require().NoError(err)
+
+	suite.Eventually(func() bool { return !sw.Paused() }, time.Second, 10*time.Millisecond)
+}
+
+func (suite *ConfigMapWatcherSuite) TestParse() {
+	until := time.Date(2030, 1, 1, 0, 0, 0, 0, time.UTC)
+
+	for _, tt := range []struct {
+		data   map[string]string
+		paused bool
+		until  time.Time
+	}{
+		{map[string]string{}, false, time.Time{}},
+		{map[string]string{KeyPaused: "false"}, false, time.Time{}},
+		{map[string]string{KeyPaused: "true"}, true, time.Time{}},
+		{map[string]string{KeyPaused: "true", KeyUntil: "2030-01-01T00:00:00Z"}, true, until},
+		{map[string]string{KeyPaused: "false", KeyUntil: "2030-01-01T00:00:00Z"}, false, time.Time{}},
+		{map[string]string{KeyPaused: "yes please"}, true, time.Time{}},
+		{map[string]string{KeyPaused: "true", KeyUntil: "tomorrow"}, true, time.Time{}},
+	} {
+		watcher := NewConfigMapWatcher(fake.NewSimpleClientset(), logger, "chaoskube", "chaoskube-pause", NewSwitch(logger))
+
+		paused, parsedUntil := watcher.parse(tt.data)
+		suite.Equal(tt.paused, paused)
+		suite.True(tt.until.Equal(parsedUntil))
+	}
+}
+
+func TestConfigMapWatcherSuite(t *testing.T) {
+	suite.Run(t, new(ConfigMapWatcherSuite))
+}
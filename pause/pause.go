@@ -0,0 +1,146 @@
+package pause
+
+import (
+	"sort"
+	"sync"
+	"time"
+
+	log "github.com/sirupsen/logrus"
+
+	"github.com/linki/chaoskube/metrics"
+)
+
+// Switch tracks whether chaoskube is paused. It can be paused by several sources,
+// such as a ConfigMap, and stays paused as long as at least one of them pauses it.
+type Switch struct {
+	// an instance of logrus.StdLogger to write log messages to
+	Logger log.FieldLogger
+	// a function to retrieve the current time
+	Now func() time.Time
+
+	mu      sync.Mutex
+	sources map[string]time.Time
+	// timer expires the next pause when it runs out, nil if none does
+	timer *time.Timer
+}
+
+// Status describes the paused state of a Switch.
+type Status struct {
+	// whether chaoskube is paused
+	Paused bool `json:"paused"`
+	// the time at which chaoskube resumes automatically, zero if it doesn't
+	Until time.Time `json:"until,omitzero"`
+	// the sources that currently pause chaoskube
+	Sources []string `json:"sources,omitempty"`
+}
+
+// NewSwitch returns a new Switch that isn't paused.
+func NewSwitch(logger log.FieldLogger) *Switch {
+	return &Switch{
+		Logger:  logger,
+		Now:     time.Now,
+		sources: make(map[string]time.Time),
+	}
+}
+
+// Pause pauses chaoskube on behalf of the given source. A zero until keeps it paused
+// until it is resumed explicitly, otherwise it resumes automatically at the given time.
+func (s *Switch) Pause(source string, until time.Time) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.sources[source] = until
+
+	fields := log.Fields{"source": source}
+	if !until.IsZero() {
+		fields["until"] = until
+	}
+	s.Logger.WithFields(fields).Info("pausing chaos")
+
+	s.expire()
+}
+
+// Resume lifts the pause of the given source.
+func (s *Switch) Resume(source string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if _, ok := s.sources[source]; ok {
+		delete(s.sources, source)
+		s.Logger.WithField("source", source).Info("resuming chaos")
+	}
+
+	s.expire()
+}
+
+// Paused returns true iff at least one source pauses chaoskube.
+func (s *Switch) Paused() bool {
+	return s.Status().Paused
+}
+
+// Status returns the current paused state.
+func (s *Switch) Status() Status {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.expire()
+
+	status := Status{Paused: len(s.sources) > 0}
+	indefinitely := false
+
+	for source, until := range s.sources {
+		status.Sources = append(status.Sources, source)
+
+		if until.IsZero() {
+			indefinitely = true
+		}
+		if until.After(status.Until) {
+			status.Until = until
+		}
+	}
+	sort.Strings(status.Sources)
+
+	if indefinitely {
+		status.Until = time.Time{}
+	}
+
+	return status
+}
+
+// expire removes all sources whose pause has run out and updates the paused metric.
+// It schedules itself for when the next pause runs out, so that the metric doesn't wait
+// for the next call to Status. It must be called with the lock held.
+func (s *Switch) expire() {
+	now := s.Now()
+
+	next := time.Time{}
+	for source, until := range s.sources {
+		if until.IsZero() {
+			continue
+		}
+		if !now.Before(until) {
+			delete(s.sources, source)
+			s.Logger.WithFields(log.Fields{"source": source, "until": until}).Info("pause expired, resuming chaos")
+		} else if next.IsZero() || until.Before(next) {
+			next = until
+		}
+	}
+
+	if s.timer != nil {
+		s.timer.Stop()
+		s.timer = nil
+	}
+	if !next.IsZero() {
+		s.timer = time.AfterFunc(next.Sub(now), func() {
+			s.mu.Lock()
+			defer s.mu.Unlock()
+			s.expire()
+		})
+	}
+
+	if len(s.sources) > 0 {
+		metrics.Paused.Set(1)
+	} else {
+		metrics.Paused.Set(0)
+	}
+}
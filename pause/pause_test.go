@@ -0,0 +1,91 @@
+package pause
+
+import (
+	"testing"
+	"time"
+
+	promtest "github.com/prometheus/client_golang/prometheus/testutil"
+	log "github.com/sirupsen/logrus"
+	"github.com/sirupsen/logrus/hooks/test"
+
+	"github.com/linki/chaoskube/internal/testutil"
+	"github.com/linki/chaoskube/metrics"
+
+	"github.com/stretchr/testify/suite"
+)
+
+type SwitchSuite struct {
+	testutil.TestSuite
+}
+
+var (
+	logger, logOutput = test.NewNullLogger()
+)
+
+func (suite *SwitchSuite) SetupTest() {
+	logger.SetLevel(log.DebugLevel)
+	logOutput.Reset()
+}
+
+func (suite *SwitchSuite) TestPauseAndResume() {
+	sw := NewSwitch(logger)
+	suite.False(sw.Paused())
+
+	sw.Pause("foo", time.Time{})
+	suite.True(sw.Paused())
+	suite.AssertLog(logOutput, log.InfoLevel, "pausing chaos", log.Fields{"source": "foo"})
+
+	sw.Pause("bar", time.Time{})
+	sw.Resume("foo")
+	suite.True(sw.Paused())
+	suite.Equal(Status{Paused: true, Sources: []string{"bar"}}, sw.Status())
+
+	sw.Resume("bar")
+	suite.False(sw.Paused())
+	suite.Equal(Status{}, sw.Status())
+	suite.AssertLog(logOutput, log.InfoLevel, "resuming chaos", log.Fields{"source": "bar"})
+}
+
+func (suite *SwitchSuite) TestExpiry() {
+	now := time.Date(1869, 9, 24, 15, 4, 5, 0, time.UTC)
+
+	sw := NewSwitch(logger)
+	sw.Now = func() time.Time { return now }
+
+	sw.Pause("foo", now.Add(time.Hour))
+	sw.Pause("bar", now.Add(2*time.Hour))
+	suite.Equal(Status{Paused: true, Until: now.Add(2 * time.Hour), Sources: []string{"bar", "foo"}}, sw.Status())
+
+	now = now.Add(time.Hour)
+	suite.Equal(Status{Paused: true, Until: now.Add(time.Hour), Sources: []string{"bar"}}, sw.Status())
+	suite.AssertLog(logOutput, log.InfoLevel, "pause expired, resuming chaos", log.Fields{"source": "foo"})
+
+	sw.Pause("baz", time.Time{})
+	suite.Equal(Status{Paused: true, Sources: []string{"bar", "baz"}}, sw.Status())
+
+	now = now.Add(time.Hour)
+	sw.Resume("baz")
+	suite.False(sw.Paused())
+}
+
+func (suite *SwitchSuite) TestExpiryUpdatesMetric() {
+	sw := NewSwitch(logger)
+
+	sw.Pause("foo", time.Now().Add(50*time.Millisecond))
+	suite.Equal(1.0, promtest.ToFloat64(metrics.Paused))
+
+	// the metric is reset without asking for the status
+	suite.Eventually(func() bool {
+		return promtest.ToFloat64(metrics.Paused) == 0
+	}, time.Second, 10*time.Millisecond)
+}
+
+func (suite *SwitchSuite) TestPauseInThePast() {
+	sw := NewSwitch(logger)
+	sw.Pause("foo", time.Now().Add(-time.Minute))
+	suite.False(sw.Paused())
+}
+
+func TestSwitchSuite(t *testing.T) {
+	suite.Run(t, new(SwitchSuite))
+}
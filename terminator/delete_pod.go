@@ -27,6 +27,16 @@ func NewDeletePodTerminator(client kubernetes.Interface, logger log.FieldLogger,
 	}
 }
 
+// Name returns the name of the terminator.
+func (t *DeletePodTerminator) Name() string {
+	return "DeletePod"
+}
+
+// GracePeriod returns the grace period given to victims, negative values use the pod's own.
+func (t *DeletePodTerminator) GracePeriod() time.Duration {
+	return t.gracePeriod
+}
+
 // Terminate sends a request to Kubernetes to delete the pod.
 func (t *DeletePodTerminator) Terminate(ctx context.Context, victim v1.Pod) error {
 	t.logger.WithFields(log.Fields{
@@ -0,0 +1,45 @@
+package terminator
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+)
+
+// Classes of termination errors as returned by ErrorReason.
+const (
+	ReasonNotFound         = "not_found"
+	ReasonForbidden        = "forbidden"
+	ReasonDisruptionBudget = "disruption_budget"
+	ReasonTimeout          = "timeout"
+	ReasonOther            = "other"
+)
+
+// ErrorReason classifies an error returned by a Terminator.
+func ErrorReason(err error) string {
+	switch {
+	case apierrors.IsNotFound(err):
+		return ReasonNotFound
+	case apierrors.IsForbidden(err):
+		return ReasonForbidden
+	case apierrors.IsTooManyRequests(err):
+		// the eviction API refuses evictions that would violate a PodDisruptionBudget this way
+		return ReasonDisruptionBudget
+	case apierrors.IsTimeout(err), apierrors.IsServerTimeout(err), errors.Is(err, context.DeadlineExceeded):
+		return ReasonTimeout
+	default:
+		return ReasonOther
+	}
+}
+
+// Name returns the name of the given terminator, e.g. to label metrics. Terminators can
+// report their name by implementing a Name method, otherwise the type name is used.
+func Name(t Terminator) string {
+	if named, ok := t.(interface{ Name() string }); ok {
+		return named.Name()
+	}
+	return strings.TrimPrefix(fmt.Sprintf("%T", t), "*")
+}
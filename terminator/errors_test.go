@@ -0,0 +1,51 @@
+package terminator
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+
+	v1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+
+	"github.com/stretchr/testify/suite"
+)
+
+type ErrorsSuite struct {
+	suite.Suite
+}
+
+type anonymousTerminator struct{}
+
+func (anonymousTerminator) Terminate(context.Context, v1.Pod) error {
+	return nil
+}
+
+func (suite *ErrorsSuite) TestErrorReason() {
+	pods := schema.GroupResource{Resource: "pods"}
+
+	for _, tt := range []struct {
+		err    error
+		reason string
+	}{
+		{apierrors.NewNotFound(pods, "foo"), ReasonNotFound},
+		{apierrors.NewForbidden(pods, "foo", errors.New("denied")), ReasonForbidden},
+		{apierrors.NewTooManyRequests("Cannot evict pod as it would violate the pod's disruption budget.", 0), ReasonDisruptionBudget},
+		{apierrors.NewTimeoutError("timeout", 0), ReasonTimeout},
+		{fmt.Errorf("wrapped: %w", context.DeadlineExceeded), ReasonTimeout},
+		{errors.New("boom"), ReasonOther},
+	} {
+		suite.Equal(tt.reason, ErrorReason(tt.err), tt.err.Error())
+	}
+}
+
+func (suite *ErrorsSuite) TestName() {
+	suite.Equal("DeletePod", Name(NewDeletePodTerminator(nil, logger, 0)))
+	suite.Equal("terminator.anonymousTerminator", Name(anonymousTerminator{}))
+}
+
+func TestErrorsSuite(t *testing.T) {
+	suite.Run(t, new(ErrorsSuite))
+}
@@ -2,6 +2,7 @@ package terminator
 
 import (
 	"context"
+	"time"
 
 	v1 "k8s.io/api/core/v1"
 )
@@ -11,3 +12,12 @@ type Terminator interface {
 	// Terminate terminates the given pod.
 	Terminate(ctx context.Context, victim v1.Pod) error
 }
+
+// GracePeriod returns the grace period the given terminator gives its victims if it has one,
+// where negative values mean the pod's own grace period.
+func GracePeriod(t Terminator) (time.Duration, bool) {
+	if graceful, ok := t.(interface{ GracePeriod() time.Duration }); ok {
+		return graceful.GracePeriod(), true
+	}
+	return 0, false
+}
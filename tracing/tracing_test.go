@@ -0,0 +1,85 @@
+package tracing
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+
+	collectortrace "go.opentelemetry.io/proto/otlp/collector/trace/v1"
+	"google.golang.org/protobuf/proto"
+
+	"github.com/stretchr/testify/suite"
+)
+
+type TracingSuite struct {
+	suite.Suite
+}
+
+// collector is an in-process OTLP/HTTP trace receiver.
+type collector struct {
+	mu       sync.Mutex
+	requests []*collectortrace.ExportTraceServiceRequest
+}
+
+func (c *collector) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if r.URL.Path != "/v1/traces" {
+		http.NotFound(w, r)
+		return
+	}
+
+	body, err := io.ReadAll(r.Body)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	request := &collectortrace.ExportTraceServiceRequest{}
+	if err := proto.Unmarshal(body, request); err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	c.mu.Lock()
+	c.requests = append(c.requests, request)
+	c.mu.Unlock()
+
+	w.Header().Set("Content-Type", "application/x-protobuf")
+	response, _ := proto.Marshal(&collectortrace.ExportTraceServiceResponse{})
+	w.Write(response)
+}
+
+func (suite *TracingSuite) TestExport() {
+	collector := &collector{}
+	server := httptest.NewServer(collector)
+	defer server.Close()
+
+	provider, err := NewTracerProvider(context.Background(), server.URL, "v1.2.3")
+	suite.Require().NoError(err)
+
+	_, span := provider.Tracer("test").Start(context.Background(), "TerminateVictims")
+	span.End()
+
+	suite.Require().NoError(provider.Shutdown(context.Background()))
+
+	collector.mu.Lock()
+	defer collector.mu.Unlock()
+
+	suite.Require().Len(collector.requests, 1)
+	resourceSpans := collector.requests[0].ResourceSpans[0]
+
+	attributes := map[string]string{}
+	for _, attribute := range resourceSpans.Resource.Attributes {
+		attributes[attribute.Key] = attribute.Value.GetStringValue()
+	}
+	suite.Equal(ServiceName, attributes["service.name"])
+	suite.Equal("v1.2.3", attributes["service.version"])
+
+	suite.Equal("TerminateVictims", resourceSpans.ScopeSpans[0].Spans[0].Name)
+}
+
+func TestTracingSuite(t *testing.T) {
+	suite.Run(t, new(TracingSuite))
+}
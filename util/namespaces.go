@@ -0,0 +1,72 @@
+package util
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes"
+)
+
+// DefaultNamespaceCacheTTL is how long a NamespaceCache keeps a namespace before looking it up again.
+const DefaultNamespaceCacheTTL = time.Minute
+
+// NamespaceCache looks up namespaces and keeps them for a while, so that looking at the
+// annotations of a victim's namespace doesn't cost a request to the API server every time.
+// It's safe for concurrent use.
+type NamespaceCache struct {
+	Client kubernetes.Interface
+	TTL    time.Duration
+	// Now returns the current time, time.Now if nil.
+	Now func() time.Time
+
+	mu      sync.Mutex
+	entries map[string]namespaceEntry
+}
+
+// namespaceEntry is a cached namespace along with when it was looked up.
+type namespaceEntry struct {
+	namespace *v1.Namespace
+	fetched   time.Time
+}
+
+// NewNamespaceCache returns a NamespaceCache that keeps namespaces for the given duration.
+func NewNamespaceCache(client kubernetes.Interface, ttl time.Duration) *NamespaceCache {
+	return &NamespaceCache{
+		Client:  client,
+		TTL:     ttl,
+		entries: map[string]namespaceEntry{},
+	}
+}
+
+// Get returns the namespace with the given name, looking it up if it isn't cached or
+// expired. Failed lookups aren't cached.
+func (c *NamespaceCache) Get(ctx context.Context, name string) (*v1.Namespace, error) {
+	now := time.Now
+	if c.Now != nil {
+		now = c.Now
+	}
+
+	c.mu.Lock()
+	entry, ok := c.entries[name]
+	c.mu.Unlock()
+	if ok && now().Sub(entry.fetched) < c.TTL {
+		return entry.namespace, nil
+	}
+
+	namespace, err := c.Client.CoreV1().Namespaces().Get(ctx, name, metav1.GetOptions{})
+	if err != nil {
+		return nil, err
+	}
+
+	c.mu.Lock()
+	if c.entries == nil {
+		c.entries = map[string]namespaceEntry{}
+	}
+	c.entries[name] = namespaceEntry{namespace: namespace, fetched: now()}
+	c.mu.Unlock()
+
+	return namespace, nil
+}
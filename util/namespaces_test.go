@@ -0,0 +1,71 @@
+package util
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/suite"
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes/fake"
+)
+
+type NamespaceCacheSuite struct {
+	suite.Suite
+}
+
+func (suite *NamespaceCacheSuite) TestGet() {
+	client := fake.NewSimpleClientset(&v1.Namespace{ObjectMeta: metav1.ObjectMeta{
+		Name:        "default",
+		Annotations: map[string]string{"foo": "bar"},
+	}})
+
+	now := time.Date(2026, 1, 2, 3, 4, 5, 0, time.UTC)
+	cache := NewNamespaceCache(client, time.Minute)
+	cache.Now = func() time.Time { return now }
+
+	lookups := func() int {
+		count := 0
+		for _, action := range client.Actions() {
+			if action.GetVerb() == "get" && action.GetResource().Resource == "namespaces" {
+				count++
+			}
+		}
+		return count
+	}
+
+	namespace, err := cache.Get(context.Background(), "default")
+	suite.Require().NoError(err)
+	suite.Equal("bar", namespace.Annotations["foo"])
+	suite.Equal(1, lookups())
+
+	// cached namespaces aren't looked up again
+	updated := namespace.DeepCopy()
+	updated.Annotations["foo"] = "baz"
+	_, err = client.CoreV1().Namespaces().Update(context.Background(), updated, metav1.UpdateOptions{})
+	suite.Require().NoError(err)
+
+	namespace, err = cache.Get(context.Background(), "default")
+	suite.Require().NoError(err)
+	suite.Equal("bar", namespace.Annotations["foo"])
+	suite.Equal(1, lookups())
+
+	// expired namespaces are looked up again
+	now = now.Add(time.Minute)
+	namespace, err = cache.Get(context.Background(), "default")
+	suite.Require().NoError(err)
+	suite.Equal("baz", namespace.Annotations["foo"])
+	suite.Equal(2, lookups())
+
+	// failed lookups aren't cached
+	for range 2 {
+		_, err = cache.Get(context.Background(), "unknown")
+		suite.Error(err)
+	}
+	suite.Equal(4, lookups())
+}
+
+func TestNamespaceCacheSuite(t *testing.T) {
+	suite.Run(t, new(NamespaceCacheSuite))
+}
@@ -8,6 +8,8 @@ import (
 
 	v1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/selection"
 	"k8s.io/apimachinery/pkg/types"
 )
 
@@ -135,6 +137,72 @@ func FormatDays(days []time.Time) []string {
 	return formattedDays
 }
 
+// NameSelector matches plain names, such as namespaces, against a selector like "default,!kube-system".
+// Names without a prefix are included, names prefixed with "!" are excluded.
+type NameSelector struct {
+	included []labels.Requirement
+	excluded []labels.Requirement
+}
+
+// NewNameSelector splits the requirements of the given selector into including and excluding groups.
+// It returns an error if the selector uses any other operator.
+func NewNameSelector(selector labels.Selector) (NameSelector, error) {
+	reqs, _ := selector.Requirements()
+	nameSelector := NameSelector{}
+
+	for _, req := range reqs {
+		switch req.Operator() {
+		case selection.Exists:
+			nameSelector.included = append(nameSelector.included, req)
+		case selection.DoesNotExist:
+			nameSelector.excluded = append(nameSelector.excluded, req)
+		default:
+			return NameSelector{}, fmt.Errorf("unsupported operator: %s", req.Operator())
+		}
+	}
+
+	return nameSelector, nil
+}
+
+// Matches returns true iff the given name is included and not excluded by the selector.
+func (s NameSelector) Matches(name string) bool {
+	// if there aren't any including requirements, we're in by default
+	included := len(s.included) == 0
+
+	// convert the name to an equivalent label selector
+	set := labels.Set{name: ""}
+
+	// include name if one including requirement matches
+	for _, req := range s.included {
+		if req.Matches(set) {
+			included = true
+			break
+		}
+	}
+
+	// exclude name if it is filtered out by at least one excluding requirement
+	for _, req := range s.excluded {
+		if !req.Matches(set) {
+			included = false
+			break
+		}
+	}
+
+	return included
+}
+
+// OwnerOf returns the controlling owner reference of the given pod. If there's
+// no controller it returns the first owner reference, and nil if there's none.
+func OwnerOf(pod v1.Pod) *metav1.OwnerReference {
+	if ref := metav1.GetControllerOf(&pod); ref != nil {
+		return ref
+	}
+	if refs := pod.GetOwnerReferences(); len(refs) > 0 {
+		return &refs[0]
+	}
+	return nil
+}
+
 // NewPod returns a new pod instance for testing purposes.
 func NewPod(namespace, name string, phase v1.PodPhase) v1.Pod {
 	return NewPodWithOwner(namespace, name, phase, "")
@@ -6,6 +6,9 @@ import (
 
 	"github.com/stretchr/testify/suite"
 	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/types"
 )
 
 type Suite struct {
@@ -388,6 +391,52 @@ func (suite *Suite) TestFormatDays() {
 	}
 }
 
+func (suite *Suite) TestNameSelector() {
+	for _, tt := range []struct {
+		selector string
+		name     string
+		expected bool
+	}{
+		{"", "default", true},
+		{"default", "default", true},
+		{"default", "testing", false},
+		{"default,testing", "testing", true},
+		{"!testing", "default", true},
+		{"!testing", "testing", false},
+		{"default,!testing", "testing", false},
+		{"default,!default", "default", false},
+	} {
+		parsed, err := labels.Parse(tt.selector)
+		suite.Require().NoError(err)
+
+		selector, err := NewNameSelector(parsed)
+		suite.Require().NoError(err)
+
+		suite.Equal(tt.expected, selector.Matches(tt.name), tt.selector)
+	}
+}
+
+func (suite *Suite) TestNameSelectorUnsupportedOperator() {
+	parsed, err := labels.Parse("foo=bar")
+	suite.Require().NoError(err)
+
+	_, err = NewNameSelector(parsed)
+	suite.EqualError(err, "unsupported operator: =")
+}
+
+func (suite *Suite) TestOwnerOf() {
+	controller := true
+
+	pod := NewPod("default", "foo", v1.PodRunning)
+	suite.Nil(OwnerOf(pod))
+
+	pod = NewPodWithOwner("default", "foo", v1.PodRunning, "bar")
+	suite.Equal(types.UID("bar"), OwnerOf(pod).UID)
+
+	pod.OwnerReferences = append(pod.OwnerReferences, metav1.OwnerReference{UID: "baz", Controller: &controller})
+	suite.Equal(types.UID("baz"), OwnerOf(pod).UID)
+}
+
 func (suite *Suite) TestNewPod() {
 	pod := NewPod("namespace", "name", "phase")
 